@@ -0,0 +1,395 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"iter"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+var errNoData = errors.New("the request body must be provided with --data")
+
+// input contains the options of a command besides its positional arguments.
+type input struct {
+	// data is the JSON body of create commands.
+	data io.Reader
+	// limit is the maximum number of listed items, all the pages are read
+	// when it is lower than 1.
+	limit int
+}
+
+// command is an action performed on a resource.
+type command struct {
+	// args names the positional arguments, optional ones are in brackets.
+	args []string
+	run  func(ctx context.Context, c *mollie.Client, args []string, in *input) (any, error)
+}
+
+// accepts reports if the number of positional arguments is valid.
+func (cmd command) accepts(n int) bool {
+	required := 0
+
+	for _, a := range cmd.args {
+		if !strings.HasPrefix(a, "[") {
+			required++
+		}
+	}
+
+	return n >= required && n <= len(cmd.args)
+}
+
+// resource groups the commands of an API resource.
+type resource struct {
+	// columns are the fields printed by the table and CSV outputs.
+	columns []string
+	actions map[string]command
+}
+
+// resources returns the resources supported by the CLI, by name.
+func resources() map[string]resource {
+	return map[string]resource{
+		"payments":      paymentsResource(),
+		"refunds":       refundsResource(),
+		"customers":     customersResource(),
+		"mandates":      mandatesResource(),
+		"subscriptions": subscriptionsResource(),
+		"payment-links": paymentLinksResource(),
+		"webhooks":      webhooksResource(),
+		"settlements":   settlementsResource(),
+	}
+}
+
+func paymentsResource() resource {
+	return resource{
+		columns: []string{"id", "status", "amount.value", "amount.currency", "description", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"payment"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Payments.Get(ctx, a[0], nil))
+				},
+			},
+			"list": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					return collect(c.Payments.All(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					p, err := decode[mollie.CreatePayment](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Payments.Create(ctx, p, nil))
+				},
+			},
+			"cancel": {
+				args: []string{"payment"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Payments.Cancel(ctx, a[0]))
+				},
+			},
+		},
+	}
+}
+
+func refundsResource() resource {
+	return resource{
+		columns: []string{"id", "paymentId", "status", "amount.value", "amount.currency", "description", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"payment", "refund"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Refunds.GetPaymentRefund(ctx, a[0], a[1], nil))
+				},
+			},
+			"list": {
+				args: []string{"[payment]"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					if len(a) == 1 {
+						return collect(c.Refunds.AllPaymentRefunds(ctx, a[0], nil), in.limit)
+					}
+
+					return collect(c.Refunds.All(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				args: []string{"payment"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					r, err := decode[mollie.CreatePaymentRefund](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Refunds.CreatePaymentRefund(ctx, a[0], r, nil))
+				},
+			},
+			"cancel": {
+				args: []string{"payment", "refund"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return none(c.Refunds.CancelPaymentRefund(ctx, a[0], a[1]))
+				},
+			},
+		},
+	}
+}
+
+func customersResource() resource {
+	return resource{
+		columns: []string{"id", "name", "email", "locale", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"customer"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Customers.Get(ctx, a[0]))
+				},
+			},
+			"list": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					return collect(c.Customers.All(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					cc, err := decode[mollie.CreateCustomer](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Customers.Create(ctx, cc))
+				},
+			},
+			"delete": {
+				args: []string{"customer"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return none(c.Customers.Delete(ctx, a[0]))
+				},
+			},
+		},
+	}
+}
+
+func mandatesResource() resource {
+	return resource{
+		columns: []string{"id", "method", "status", "mandateReference", "signatureDate", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"customer", "mandate"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Mandates.Get(ctx, a[0], a[1]))
+				},
+			},
+			"list": {
+				args: []string{"customer"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					return collect(c.Mandates.All(ctx, a[0], nil), in.limit)
+				},
+			},
+			"create": {
+				args: []string{"customer"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					m, err := decode[mollie.CreateMandate](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Mandates.Create(ctx, a[0], m))
+				},
+			},
+			"revoke": {
+				args: []string{"customer", "mandate"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return none(c.Mandates.Revoke(ctx, a[0], a[1]))
+				},
+			},
+		},
+	}
+}
+
+func subscriptionsResource() resource {
+	return resource{
+		columns: []string{"id", "status", "amount.value", "amount.currency", "interval", "description", "nextPaymentDate"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"customer", "subscription"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Subscriptions.Get(ctx, a[0], a[1]))
+				},
+			},
+			"list": {
+				args: []string{"[customer]"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					if len(a) == 1 {
+						return collect(c.Subscriptions.AllForCustomer(ctx, a[0], nil), in.limit)
+					}
+
+					return collect(c.Subscriptions.AllSubscriptions(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				args: []string{"customer"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, in *input) (any, error) {
+					s, err := decode[mollie.CreateSubscription](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Subscriptions.Create(ctx, a[0], s))
+				},
+			},
+			"cancel": {
+				args: []string{"customer", "subscription"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Subscriptions.Cancel(ctx, a[0], a[1]))
+				},
+			},
+		},
+	}
+}
+
+func paymentLinksResource() resource {
+	return resource{
+		columns: []string{"id", "description", "amount.value", "amount.currency", "paidAt", "expiresAt", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"link"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.PaymentLinks.Get(ctx, a[0]))
+				},
+			},
+			"list": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					return collect(c.PaymentLinks.All(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					pl, err := decode[mollie.PaymentLink](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.PaymentLinks.Create(ctx, pl, nil))
+				},
+			},
+			"delete": {
+				args: []string{"link"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return none(c.PaymentLinks.Delete(ctx, a[0]))
+				},
+			},
+		},
+	}
+}
+
+func webhooksResource() resource {
+	return resource{
+		columns: []string{"id", "name", "url", "status", "eventTypes", "createdAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"webhook"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Webhooks.Get(ctx, a[0]))
+				},
+			},
+			"list": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					return collect(c.Webhooks.All(ctx, nil), in.limit)
+				},
+			},
+			"create": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					wh, err := decode[mollie.CreateWebhook](in)
+					if err != nil {
+						return nil, err
+					}
+
+					return result(c.Webhooks.Create(ctx, wh))
+				},
+			},
+			"delete": {
+				args: []string{"webhook"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return none(c.Webhooks.Delete(ctx, a[0]))
+				},
+			},
+		},
+	}
+}
+
+func settlementsResource() resource {
+	return resource{
+		columns: []string{"id", "reference", "status", "amount.value", "amount.currency", "settledAt"},
+		actions: map[string]command{
+			"get": {
+				args: []string{"settlement"},
+				run: func(ctx context.Context, c *mollie.Client, a []string, _ *input) (any, error) {
+					return result(c.Settlements.Get(ctx, a[0]))
+				},
+			},
+			"list": {
+				run: func(ctx context.Context, c *mollie.Client, _ []string, in *input) (any, error) {
+					return collect(c.Settlements.All(ctx, nil), in.limit)
+				},
+			},
+		},
+	}
+}
+
+// result drops the response of a service call returning a resource.
+func result[T any](_ *mollie.Response, v T, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// none adapts a service call without a resource in its response.
+func none(_ *mollie.Response, err error) (any, error) {
+	return nil, err
+}
+
+// collect reads up to limit items from seq, requesting the next pages as
+// needed, all the items are read when limit is lower than 1.
+func collect[T any](seq iter.Seq2[T, error], limit int) (any, error) {
+	items := []T{}
+
+	for v, err := range seq {
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, v)
+		if limit > 0 && len(items) == limit {
+			break
+		}
+	}
+
+	return items, nil
+}
+
+// decode reads the body of a create command, unknown fields are rejected
+// to catch typos before the request is sent.
+func decode[T any](in *input) (T, error) {
+	var v T
+
+	if in.data == nil {
+		return v, errNoData
+	}
+
+	dec := json.NewDecoder(in.data)
+	dec.DisallowUnknownFields()
+
+	if err := dec.Decode(&v); err != nil {
+		return v, fmt.Errorf("decoding --data: %w", err)
+	}
+
+	return v, nil
+}
@@ -0,0 +1,270 @@
+// Command mollie performs day-to-day operations against the Mollie API.
+//
+// Usage:
+//
+//	mollie [flags] <resource> <action> [arguments]
+//
+// For example:
+//
+//	mollie payments get tr_7UhSN1zuXS
+//	mollie refunds list tr_7UhSN1zuXS --output table
+//	mollie customers create --data '{"name":"Customer A","email":"customer@example.org"}'
+//	mollie subscriptions cancel cst_8wmqcHMN4U sub_rVKGtNd6s3
+//
+// The token is read from MOLLIE_API_TOKEN, or from MOLLIE_ORG_TOKEN when
+// the former is not set or --org is given. Lists read all the pages unless
+// --limit is given.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"slices"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// Exit codes of the command.
+const (
+	exitOK    = 0
+	exitError = 1
+	exitUsage = 2
+)
+
+var errUsage = errors.New("invalid usage")
+
+// options contains the flags of the command.
+type options struct {
+	profile  string
+	testmode bool
+	org      bool
+	output   string
+	columns  string
+	limit    int
+	data     string
+}
+
+// app contains the dependencies of the command, replaced in tests.
+type app struct {
+	stdin      io.Reader
+	stdout     io.Writer
+	stderr     io.Writer
+	httpClient *http.Client
+	baseURL    *url.URL
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+
+	a := &app{stdin: os.Stdin, stdout: os.Stdout, stderr: os.Stderr}
+	code := a.run(ctx, os.Args[1:])
+
+	stop()
+	os.Exit(code)
+}
+
+// run executes the command line and returns the exit code.
+func (a *app) run(ctx context.Context, args []string) int {
+	opts, pos, err := a.parse(args)
+	if errors.Is(err, flag.ErrHelp) {
+		return exitOK
+	}
+
+	if err == nil {
+		err = a.execute(ctx, opts, pos)
+	}
+
+	switch {
+	case err == nil:
+		return exitOK
+	case errors.Is(err, errUsage):
+		fmt.Fprintf(a.stderr, "mollie: %s\n\n", err)
+		a.usage()
+
+		return exitUsage
+	default:
+		fmt.Fprintf(a.stderr, "mollie: %s\n", err)
+
+		return exitError
+	}
+}
+
+func (a *app) execute(ctx context.Context, opts *options, pos []string) error {
+	if len(pos) < 2 {
+		return fmt.Errorf("%w: a resource and an action are required", errUsage)
+	}
+
+	res, ok := resources()[pos[0]]
+	if !ok {
+		return fmt.Errorf("%w: unknown resource %q", errUsage, pos[0])
+	}
+
+	cmd, ok := res.actions[pos[1]]
+	if !ok {
+		return fmt.Errorf("%w: unknown action %q for %s", errUsage, pos[1], pos[0])
+	}
+
+	if !cmd.accepts(len(pos) - 2) {
+		usage := strings.Join(append([]string{"mollie", pos[0], pos[1]}, cmd.args...), " ")
+
+		return fmt.Errorf("%w: expected %s", errUsage, usage)
+	}
+
+	if !slices.Contains([]string{formatJSON, formatTable, formatCSV}, opts.output) {
+		return fmt.Errorf("%w: unknown output format %q", errUsage, opts.output)
+	}
+
+	c, err := a.client(opts)
+	if err != nil {
+		return err
+	}
+
+	in, err := a.input(opts)
+	if err != nil {
+		return err
+	}
+
+	v, err := cmd.run(ctx, c, pos[2:], in)
+	if err != nil {
+		return err
+	}
+
+	columns := res.columns
+	if opts.columns != "" {
+		columns = strings.Split(opts.columns, ",")
+	}
+
+	return write(a.stdout, opts.output, columns, v)
+}
+
+// newFlagSet returns the flags of the command, stored in opts.
+func newFlagSet(opts *options) *flag.FlagSet {
+	fs := flag.NewFlagSet("mollie", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.Usage = func() {}
+	fs.StringVar(&opts.profile, "profile", "", "profile `id` sent with organization tokens, e.g. to list payments")
+	fs.BoolVar(&opts.testmode, "testmode", false, "send the requests in test mode")
+	fs.BoolVar(&opts.org, "org", false, "authenticate with MOLLIE_ORG_TOKEN")
+	fs.StringVar(&opts.output, "output", formatJSON, "output `format`: json, table or csv")
+	fs.StringVar(&opts.columns, "columns", "", "comma separated `fields` printed by the table and csv outputs")
+	fs.IntVar(&opts.limit, "limit", 0, "maximum number of listed items, all the pages are read when 0")
+	fs.StringVar(&opts.data, "data", "", "JSON body of create commands, @file reads a file and - the standard input")
+
+	return fs
+}
+
+// parse reads the flags, which can appear before, between or after the
+// positional arguments.
+func (a *app) parse(args []string) (*options, []string, error) {
+	opts := new(options)
+	fs := newFlagSet(opts)
+
+	var pos []string
+
+	for {
+		if err := fs.Parse(args); err != nil {
+			if errors.Is(err, flag.ErrHelp) {
+				a.usage()
+
+				return nil, nil, err
+			}
+
+			return nil, nil, fmt.Errorf("%w: %w", errUsage, err)
+		}
+
+		if fs.NArg() == 0 {
+			return opts, pos, nil
+		}
+
+		pos = append(pos, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+// client creates the API client, authenticated with the token found in the
+// environment like mollie.NewClient does.
+func (a *app) client(opts *options) (*mollie.Client, error) {
+	env := mollie.APITokenEnv
+	if _, ok := os.LookupEnv(env); !ok || opts.org {
+		env = mollie.OrgTokenEnv
+	}
+
+	tkn, ok := os.LookupEnv(env)
+	if !ok || tkn == "" {
+		return nil, fmt.Errorf("set %s or %s to authenticate", mollie.APITokenEnv, mollie.OrgTokenEnv)
+	}
+
+	if opts.testmode && strings.HasPrefix(tkn, "live_") {
+		return nil, errors.New("--testmode cannot be used with a live API key, use a test API key instead")
+	}
+
+	conf := mollie.NewConfig(opts.testmode, env)
+	conf.ToggleIdempotency()
+	conf.SetProfileID(opts.profile)
+
+	c, err := mollie.NewClient(a.httpClient, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.profile != "" && !c.HasAccessToken() {
+		return nil, errors.New("--profile requires an organization or OAuth access token")
+	}
+
+	if a.baseURL != nil {
+		c.BaseURL = a.baseURL
+	}
+
+	return c, nil
+}
+
+// input returns the input of the command, reading the body given by --data.
+func (a *app) input(opts *options) (*input, error) {
+	in := &input{limit: opts.limit}
+
+	switch {
+	case opts.data == "":
+	case opts.data == "-":
+		in.data = a.stdin
+	case strings.HasPrefix(opts.data, "@"):
+		b, err := os.ReadFile(opts.data[1:])
+		if err != nil {
+			return nil, fmt.Errorf("reading --data: %w", err)
+		}
+
+		in.data = strings.NewReader(string(b))
+	default:
+		in.data = strings.NewReader(opts.data)
+	}
+
+	return in, nil
+}
+
+// usage prints the supported resources, actions and flags.
+func (a *app) usage() {
+	fmt.Fprintln(a.stderr, "Usage: mollie [flags] <resource> <action> [arguments]")
+	fmt.Fprintln(a.stderr, "\nCommands:")
+
+	all := resources()
+	for _, name := range slices.Sorted(maps.Keys(all)) {
+		actions := all[name].actions
+		for _, action := range slices.Sorted(maps.Keys(actions)) {
+			fmt.Fprintf(a.stderr, "  %s\n", strings.Join(append([]string{name, action}, actions[action].args...), " "))
+		}
+	}
+
+	fmt.Fprintln(a.stderr, "\nFlags:")
+
+	fs := newFlagSet(new(options))
+	fs.SetOutput(a.stderr)
+	fs.PrintDefaults()
+}
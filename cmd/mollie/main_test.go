@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testApp struct {
+	*app
+	mux    *http.ServeMux
+	stdout *bytes.Buffer
+	stderr *bytes.Buffer
+	url    string
+}
+
+func newTestApp(t *testing.T, env map[string]string) *testApp {
+	t.Helper()
+
+	for _, k := range []string{mollie.APITokenEnv, mollie.OrgTokenEnv} {
+		t.Setenv(k, "")
+		require.Nil(t, os.Unsetenv(k))
+	}
+
+	for k, v := range env {
+		t.Setenv(k, v)
+	}
+
+	mux := http.NewServeMux()
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL + "/")
+	require.Nil(t, err)
+
+	ta := &testApp{mux: mux, stdout: new(bytes.Buffer), stderr: new(bytes.Buffer), url: srv.URL}
+	ta.app = &app{stdin: new(bytes.Buffer), stdout: ta.stdout, stderr: ta.stderr, baseURL: u}
+
+	return ta
+}
+
+func apiKey() map[string]string {
+	return map[string]string{mollie.APITokenEnv: "test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM"}
+}
+
+func paymentsPage(next string, ids ...string) string {
+	payments := make([]map[string]any, len(ids))
+	for i, id := range ids {
+		payments[i] = map[string]any{
+			"resource":    "payment",
+			"id":          id,
+			"status":      "paid",
+			"description": "Order " + id,
+			"amount":      map[string]string{"currency": "EUR", "value": "10.00"},
+		}
+	}
+
+	links := map[string]any{}
+	if next != "" {
+		links["next"] = map[string]string{"href": next, "type": "application/hal+json"}
+	}
+
+	b, _ := json.Marshal(map[string]any{
+		"count":     len(ids),
+		"_embedded": map[string]any{"payments": payments},
+		"_links":    links,
+	})
+
+	return string(b)
+}
+
+func TestApp_ListPaginates(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+
+	ta.mux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("from") == "" {
+			_, _ = io.WriteString(w, paymentsPage(ta.url+"/v2/payments?from=tr_3", "tr_1", "tr_2"))
+
+			return
+		}
+
+		_, _ = io.WriteString(w, paymentsPage("", "tr_3"))
+	})
+
+	code := ta.run(context.Background(), []string{"payments", "list", "--output", "table"})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+
+	assert.Equal(t, `ID    STATUS  AMOUNT.VALUE  AMOUNT.CURRENCY  DESCRIPTION  CREATEDAT
+tr_1  paid    10.00         EUR              Order tr_1
+tr_2  paid    10.00         EUR              Order tr_2
+tr_3  paid    10.00         EUR              Order tr_3
+`, ta.stdout.String())
+}
+
+func TestApp_ListLimit(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+
+	pages := 0
+
+	ta.mux.HandleFunc("/v2/payments", func(w http.ResponseWriter, _ *http.Request) {
+		pages++
+		_, _ = io.WriteString(w, paymentsPage(ta.url+"/v2/payments?from=tr_3", "tr_1", "tr_2"))
+	})
+
+	code := ta.run(context.Background(), []string{"--limit", "2", "--output", "csv", "--columns", "id,amount.value",
+		"payments", "list"})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+
+	assert.Equal(t, "ID,AMOUNT.VALUE\ntr_1,10.00\ntr_2,10.00\n", ta.stdout.String())
+	assert.Equal(t, 1, pages, "the next page is not requested once the limit is reached")
+}
+
+func TestApp_Get(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+
+	ta.mux.HandleFunc("/v2/customers/cst_8wmqcHMN4U/subscriptions/sub_rVKGtNd6s3",
+		func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodGet, r.Method)
+			assert.Equal(t, "Bearer test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM", r.Header.Get(mollie.AuthHeader))
+			_, _ = io.WriteString(w, `{"resource":"subscription","id":"sub_rVKGtNd6s3","status":"active"}`)
+		})
+
+	code := ta.run(context.Background(), []string{"subscriptions", "get", "cst_8wmqcHMN4U", "sub_rVKGtNd6s3"})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+
+	var sub mollie.Subscription
+	require.Nil(t, json.Unmarshal(ta.stdout.Bytes(), &sub))
+	assert.Equal(t, "sub_rVKGtNd6s3", sub.ID)
+	assert.Equal(t, mollie.SubscriptionStatus("active"), sub.Status)
+}
+
+func TestApp_CreateWithOrgToken(t *testing.T) {
+	ta := newTestApp(t, map[string]string{mollie.OrgTokenEnv: "access_X12b31ggg23"})
+
+	var body map[string]any
+
+	ta.mux.HandleFunc("/v2/customers", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NotEmpty(t, r.Header.Get(mollie.IdempotencyKeyHeader))
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"resource":"customer","id":"cst_8wmqcHMN4U","name":"Customer A"}`)
+	})
+
+	path := filepath.Join(t.TempDir(), "customer.json")
+	require.Nil(t, os.WriteFile(path, []byte(`{"name":"Customer A","email":"customer@example.org"}`), 0o600))
+
+	code := ta.run(context.Background(), []string{
+		"customers", "create", "--data", "@" + path, "--testmode", "--profile", "pfl_3RkSN1zuPE", "--output", "table",
+	})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+
+	assert.Equal(t, map[string]any{
+		"name":     "Customer A",
+		"email":    "customer@example.org",
+		"testmode": true,
+	}, body, "customers do not accept a profileId")
+	assert.Contains(t, ta.stdout.String(), "cst_8wmqcHMN4U  Customer A")
+}
+
+func TestApp_Profile(t *testing.T) {
+	ta := newTestApp(t, map[string]string{mollie.OrgTokenEnv: "access_X12b31ggg23"})
+
+	var queries []string
+
+	ta.mux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			queries = append(queries, r.URL.Query().Get("profileId"))
+			_, _ = io.WriteString(w, paymentsPage(""))
+
+			return
+		}
+
+		var body map[string]any
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "pfl_3RkSN1zuPE", body["profileId"])
+
+		w.WriteHeader(http.StatusCreated)
+		_, _ = io.WriteString(w, `{"resource":"payment","id":"tr_7UhSN1zuXS"}`)
+	})
+	ta.mux.HandleFunc("/v2/payments/tr_7UhSN1zuXS", func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.Query().Get("profileId"))
+		_, _ = io.WriteString(w, `{"resource":"payment","id":"tr_7UhSN1zuXS"}`)
+	})
+
+	for _, args := range [][]string{
+		{"payments", "list"},
+		{"payments", "get", "tr_7UhSN1zuXS"},
+		{"payments", "create", "--data", `{"description":"Order #12345"}`},
+	} {
+		code := ta.run(context.Background(), append(args, "--profile", "pfl_3RkSN1zuPE"))
+		require.Equal(t, exitOK, code, ta.stderr.String())
+	}
+
+	assert.Equal(t, []string{"pfl_3RkSN1zuPE", ""}, queries)
+}
+
+func TestApp_CreateFromStdin(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+	ta.stdin = bytes.NewBufferString(`{"amount":{"currency":"EUR","value":"5.00"},"description":"Refund"}`)
+
+	ta.mux.HandleFunc("/v2/payments/tr_7UhSN1zuXS/refunds", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "Refund", body["description"])
+
+		_, _ = io.WriteString(w, `{"resource":"refund","id":"re_4qqhO89gsT","paymentId":"tr_7UhSN1zuXS"}`)
+	})
+
+	code := ta.run(context.Background(), []string{"refunds", "create", "tr_7UhSN1zuXS", "--data", "-"})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+	assert.Contains(t, ta.stdout.String(), `"id": "re_4qqhO89gsT"`)
+}
+
+func TestApp_Delete(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+
+	deleted := false
+
+	ta.mux.HandleFunc("/v2/payment-links/pl_4Y0eZitmBnQ6IDoMqZQKh", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.Method == http.MethodDelete
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	code := ta.run(context.Background(), []string{"payment-links", "delete", "pl_4Y0eZitmBnQ6IDoMqZQKh"})
+	require.Equal(t, exitOK, code, ta.stderr.String())
+	assert.True(t, deleted)
+	assert.Empty(t, ta.stdout.String())
+}
+
+func TestApp_APIError(t *testing.T) {
+	ta := newTestApp(t, apiKey())
+
+	ta.mux.HandleFunc("/v2/payments/tr_7UhSN1zuXS", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = io.WriteString(w, `{"status":404,"title":"Not Found","detail":"No payment exists with token tr_7UhSN1zuXS."}`)
+	})
+
+	code := ta.run(context.Background(), []string{"payments", "get", "tr_7UhSN1zuXS"})
+	assert.Equal(t, exitError, code)
+	assert.Equal(t, "mollie: 404 Not Found: No payment exists with token tr_7UhSN1zuXS.\n", ta.stderr.String())
+	assert.Empty(t, ta.stdout.String())
+}
+
+func TestApp_Errors(t *testing.T) {
+	cases := []struct {
+		name string
+		env  map[string]string
+		args []string
+		code int
+		err  string
+	}{
+		{"missing action", apiKey(), []string{"payments"}, exitUsage, "a resource and an action are required"},
+		{"unknown resource", apiKey(), []string{"orders", "get", "ord_1"}, exitUsage, `unknown resource "orders"`},
+		{"unknown action", apiKey(), []string{"settlements", "cancel", "stl_1"}, exitUsage, `unknown action "cancel"`},
+		{"missing argument", apiKey(), []string{"mandates", "get", "cst_1"}, exitUsage, "mollie mandates get customer mandate"},
+		{"unknown output", apiKey(), []string{"payments", "list", "--output", "xml"}, exitUsage, `unknown output format "xml"`},
+		{"unknown flag", apiKey(), []string{"payments", "list", "--verbose"}, exitUsage, "-verbose"},
+		{"missing token", nil, []string{"payments", "list"}, exitError, "set MOLLIE_API_TOKEN or MOLLIE_ORG_TOKEN"},
+		{
+			"live key in test mode",
+			map[string]string{mollie.APITokenEnv: "live_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM"},
+			[]string{"payments", "list", "--testmode"},
+			exitError,
+			"--testmode cannot be used with a live API key",
+		},
+		{"profile with api key", apiKey(), []string{"payments", "list", "--profile", "pfl_1"}, exitError, "--profile requires"},
+		{"missing data", apiKey(), []string{"customers", "create"}, exitError, "--data"},
+		{"unknown field", apiKey(), []string{"customers", "create", "--data", `{"nmae":"A"}`}, exitError, "nmae"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			ta := newTestApp(t, c.env)
+			ta.mux.HandleFunc("/", func(http.ResponseWriter, *http.Request) {
+				t.Error("no request must be sent")
+			})
+
+			assert.Equal(t, c.code, ta.run(context.Background(), c.args))
+			assert.Contains(t, ta.stderr.String(), c.err)
+		})
+	}
+}
+
+func TestApp_Help(t *testing.T) {
+	ta := newTestApp(t, nil)
+
+	assert.Equal(t, exitOK, ta.run(context.Background(), []string{"-h"}))
+
+	for _, want := range []string{"payments cancel payment", "refunds list [payment]", "-testmode", "-output format"} {
+		assert.Contains(t, ta.stderr.String(), want)
+	}
+}
+
+func TestWrite(t *testing.T) {
+	v := []map[string]any{
+		{"id": "wh_1", "eventTypes": []string{"payment-link.paid", "sales-invoice.paid"}, "testmode": true},
+		{"id": "wh_2", "amount": map[string]any{"value": "1.00"}},
+	}
+
+	cases := []struct {
+		format string
+		want   string
+	}{
+		{formatCSV, "ID,EVENTTYPES,TESTMODE,AMOUNT\nwh_1,\"payment-link.paid,sales-invoice.paid\",true,\nwh_2,,,\"{\"\"value\"\":\"\"1.00\"\"}\"\n"},
+		{formatJSON, "[\n  {\n    \"eventTypes\": [\n      \"payment-link.paid\",\n      \"sales-invoice.paid\"\n    ],\n" +
+			"    \"id\": \"wh_1\",\n    \"testmode\": true\n  },\n  {\n    \"amount\": {\n      \"value\": \"1.00\"\n    },\n" +
+			"    \"id\": \"wh_2\"\n  }\n]\n"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.format, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.Nil(t, write(&buf, c.format, []string{"id", "eventTypes", "testmode", "amount"}, v))
+			assert.Equal(t, c.want, buf.String())
+		})
+	}
+
+	assert.EqualError(t, write(io.Discard, "yaml", nil, v), fmt.Sprintf("unknown output format %q", "yaml"))
+}
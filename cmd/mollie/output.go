@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+	"text/tabwriter"
+)
+
+// Supported output formats.
+const (
+	formatJSON  = "json"
+	formatTable = "table"
+	formatCSV   = "csv"
+)
+
+// write prints v in the given format, table and CSV outputs contain one
+// row per item with the given columns.
+func write(w io.Writer, format string, columns []string, v any) error {
+	if v == nil {
+		return nil
+	}
+
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+
+		return enc.Encode(v)
+	case formatTable:
+		var buf bytes.Buffer
+
+		tw := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+
+		return writeRows(v, columns, func(row []string) error {
+			_, err := fmt.Fprintln(tw, strings.Join(row, "\t"))
+
+			return err
+		}, func() error {
+			if err := tw.Flush(); err != nil {
+				return err
+			}
+
+			// tabwriter pads the cells preceding empty trailing cells.
+			for line := range strings.Lines(buf.String()) {
+				if _, err := fmt.Fprintln(w, strings.TrimRight(line, " \n")); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	case formatCSV:
+		cw := csv.NewWriter(w)
+
+		return writeRows(v, columns, cw.Write, func() error {
+			cw.Flush()
+
+			return cw.Error()
+		})
+	default:
+		return fmt.Errorf("unknown output format %q", format)
+	}
+}
+
+// writeRows sends the header and a row per item of v to emit.
+func writeRows(v any, columns []string, emit func([]string) error, flush func() error) error {
+	items, err := flatten(v)
+	if err != nil {
+		return err
+	}
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = strings.ToUpper(col)
+	}
+
+	if err := emit(header); err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = field(item, col)
+		}
+
+		if err := emit(row); err != nil {
+			return err
+		}
+	}
+
+	return flush()
+}
+
+// flatten returns the JSON objects of the items of v, or of v itself when
+// it is not a slice.
+func flatten(v any) ([]map[string]any, error) {
+	values := []any{v}
+
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Slice {
+		values = make([]any, rv.Len())
+		for i := range values {
+			values[i] = rv.Index(i).Interface()
+		}
+	}
+
+	items := make([]map[string]any, 0, len(values))
+
+	for _, value := range values {
+		b, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+
+		var item map[string]any
+		if err := dec.Decode(&item); err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// field returns the value at a dotted path of a JSON object, e.g.
+// amount.value, as printed in a table cell.
+func field(item map[string]any, path string) string {
+	var v any = item
+
+	for key := range strings.SplitSeq(path, ".") {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return ""
+		}
+
+		v = obj[key]
+	}
+
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case json.Number:
+		return v.String()
+	case bool:
+		return fmt.Sprint(v)
+	case []any:
+		parts := make([]string, len(v))
+		for i, p := range v {
+			parts[i] = fmt.Sprint(p)
+		}
+
+		return strings.Join(parts, ",")
+	default:
+		b, _ := json.Marshal(v)
+
+		return string(b)
+	}
+}
@@ -0,0 +1,125 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+var errEmptyAccessToken = errors.New("the token source returned an empty access token")
+
+// AuthProvider provides the credentials sent in the Authorization header
+// of every request.
+//
+// Token is called each time a request is built or retried, implementations
+// must be safe for concurrent use and are responsible for caching and
+// refreshing their tokens.
+type AuthProvider interface {
+	// Token returns the token to send as a Bearer token.
+	Token(ctx context.Context) (string, error)
+	// IsAccessToken reports whether the tokens are access tokens, i.e.
+	// organization or OAuth tokens, for which test mode is requested using
+	// the testmode parameter instead of a test API key.
+	IsAccessToken() bool
+}
+
+// APIKey authenticates requests using a live_ or test_ API key.
+type APIKey string
+
+// Token returns the API key.
+func (k APIKey) Token(_ context.Context) (string, error) {
+	return staticToken(string(k))
+}
+
+// IsAccessToken returns false, API keys are not access tokens.
+func (k APIKey) IsAccessToken() bool {
+	return false
+}
+
+// OrganizationToken authenticates requests using an organization access token.
+type OrganizationToken string
+
+// Token returns the organization access token.
+func (t OrganizationToken) Token(_ context.Context) (string, error) {
+	return staticToken(string(t))
+}
+
+// IsAccessToken returns true.
+func (t OrganizationToken) IsAccessToken() bool {
+	return true
+}
+
+func staticToken(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return "", errEmptyAuthKey
+	}
+
+	return s, nil
+}
+
+// TokenSourceProvider authenticates requests using OAuth access tokens, e.g.
+// the tokens granted to a Mollie Connect app, refreshing them when they
+// expire.
+type TokenSourceProvider struct {
+	ts oauth2.TokenSource
+}
+
+// NewTokenSourceProvider returns an AuthProvider using the tokens of ts.
+//
+// Tokens are reused until they expire, a source created with
+// oauth2.Config.TokenSource therefore only hits the token endpoint to
+// refresh an expired access token.
+func NewTokenSourceProvider(ts oauth2.TokenSource) *TokenSourceProvider {
+	return &TokenSourceProvider{ts: oauth2.ReuseTokenSource(nil, ts)}
+}
+
+// Token returns a valid access token, refreshing it when needed.
+func (p *TokenSourceProvider) Token(_ context.Context) (string, error) {
+	tok, err := p.ts.Token()
+	if err != nil {
+		return "", err
+	}
+
+	if tok.AccessToken == "" {
+		return "", errEmptyAccessToken
+	}
+
+	return tok.AccessToken, nil
+}
+
+// IsAccessToken returns true.
+func (p *TokenSourceProvider) IsAccessToken() bool {
+	return true
+}
+
+// SetAuthProvider sets the provider used to authenticate requests, it takes
+// precedence over the token read from the environment or set using
+// WithAuthenticationValue.
+func (c *Client) SetAuthProvider(p AuthProvider) {
+	c.authProvider = p
+}
+
+// token returns the token used to authenticate a request.
+func (c *Client) token(ctx context.Context) (string, error) {
+	if c.authProvider == nil {
+		return c.authentication, nil
+	}
+
+	return c.authProvider.Token(ctx)
+}
+
+// authorize sets the Authorization header of req.
+func (c *Client) authorize(req *http.Request) error {
+	tkn, err := c.token(req.Context())
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set(AuthHeader, strings.Join([]string{TokenType, tkn}, " "))
+
+	return nil
+}
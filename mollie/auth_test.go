@@ -0,0 +1,146 @@
+package mollie
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestStaticAuthProviders(t *testing.T) {
+	tkn, err := APIKey(" test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM ").Token(context.Background())
+	require.Nil(t, err)
+	assert.Equal(t, "test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM", tkn)
+	assert.False(t, APIKey("").IsAccessToken())
+
+	_, err = OrganizationToken("").Token(context.Background())
+	assert.ErrorIs(t, err, errEmptyAuthKey)
+	assert.True(t, OrganizationToken("access_token").IsAccessToken())
+}
+
+func TestTokenSourceProvider_Refresh(t *testing.T) {
+	var refreshes atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "refresh_abc", r.PostForm.Get("refresh_token"))
+
+		n := refreshes.Add(1)
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"access_%d","refresh_token":"refresh_abc",`+
+			`"token_type":"bearer","expires_in":3600}`, n)
+	}))
+	defer srv.Close()
+
+	conf := &oauth2.Config{
+		ClientID:     "app_j9Pakf56Ajta6Y65AkdTtAv",
+		ClientSecret: "S6uQtmbd2L6ZYS2FhxHAuEptmPjq6MnW",
+		Endpoint:     oauth2.Endpoint{TokenURL: srv.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+
+	expired := &oauth2.Token{
+		AccessToken:  "access_expired",
+		RefreshToken: "refresh_abc",
+		Expiry:       time.Now().Add(-time.Minute),
+	}
+
+	p := NewTokenSourceProvider(conf.TokenSource(context.Background(), expired))
+	assert.True(t, p.IsAccessToken())
+
+	for range 3 {
+		tkn, err := p.Token(context.Background())
+		require.Nil(t, err)
+		assert.Equal(t, "access_1", tkn)
+	}
+
+	assert.Equal(t, int32(1), refreshes.Load())
+}
+
+func TestTokenSourceProvider_EmptyToken(t *testing.T) {
+	p := NewTokenSourceProvider(oauth2.StaticTokenSource(&oauth2.Token{}))
+
+	_, err := p.Token(context.Background())
+	assert.ErrorIs(t, err, errEmptyAccessToken)
+}
+
+type countingAuth struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (a *countingAuth) Token(_ context.Context) (string, error) {
+	n := a.calls.Add(1)
+
+	return fmt.Sprintf("access_%d", n), a.err
+}
+
+func (a *countingAuth) IsAccessToken() bool {
+	return true
+}
+
+func TestClient_SetAuthProvider(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	assert.False(t, tClient.HasAccessToken())
+
+	auth := &countingAuth{}
+	tClient.SetAuthProvider(auth)
+	assert.True(t, tClient.HasAccessToken())
+
+	req, err := tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+	testHeader(t, req, AuthHeader, "Bearer access_1")
+	assert.Equal(t, "true", req.URL.Query().Get("testmode"))
+
+	auth.err = fmt.Errorf("token endpoint unavailable")
+	_, err = tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	assert.ErrorContains(t, err, "authentication_error: token endpoint unavailable")
+
+	require.Nil(t, tClient.WithAuthenticationValue("test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM"))
+	assert.False(t, tClient.HasAccessToken())
+
+	req, err = tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+	testHeader(t, req, AuthHeader, "Bearer test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM")
+}
+
+func TestClient_Do_RetryReauthorizes(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tConf.SetRetryPolicy(&BackoffPolicy{MaxAttempts: 2})
+	tClient.SetAuthProvider(&countingAuth{})
+
+	var seen []string
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		seen = append(seen, r.Header.Get(AuthHeader))
+		if len(seen) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, err := tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+
+	_, err = tClient.Do(req)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Bearer access_1", "Bearer access_2"}, seen)
+}
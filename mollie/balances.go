@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -251,6 +252,13 @@ func (bs *BalancesService) List(ctx context.Context, options *ListBalancesOption
 	return bs.list(ctx, "v2/balances", options)
 }
 
+// All returns an iterator over all the organization’s balances.
+func (bs *BalancesService) All(ctx context.Context, options *ListBalancesOptions) iter.Seq2[*Balance, error] {
+	return paginate(ctx, bs.client, "v2/balances", options, func(l *BalancesList) ([]*Balance, *URL) {
+		return l.Embedded.Balances, l.Links.Next
+	})
+}
+
 // GetReport returns the balance report for the specified balance id.
 //
 // See: https://docs.mollie.com/reference/get-balance-report
@@ -291,6 +299,20 @@ func (bs *BalancesService) GetTransactionsList(
 	return bs.listTransactions(ctx, balance, options)
 }
 
+// AllTransactions returns an iterator over all the movements (transactions) for the
+// specified balance.
+func (bs *BalancesService) AllTransactions(
+	ctx context.Context,
+	balance string,
+	options *ListBalanceTransactionsOptions,
+) iter.Seq2[*BalanceTransaction, error] {
+	u := fmt.Sprintf("v2/balances/%s/transactions", balance)
+
+	return paginate(ctx, bs.client, u, options, func(l *BalanceTransactionsList) ([]*BalanceTransaction, *URL) {
+		return l.Embedded.BalanceTransactions, l.Links.Next
+	})
+}
+
 // GetPrimaryTransactionsList retrieves the list of movements (transactions) for the
 // primary balance of the account.
 //
@@ -305,6 +327,19 @@ func (bs *BalancesService) GetPrimaryTransactionsList(ctx context.Context, optio
 	return bs.listTransactions(ctx, "primary", options)
 }
 
+// AllPrimaryTransactions returns an iterator over all the movements (transactions) for the
+// primary balance of the account.
+func (bs *BalancesService) AllPrimaryTransactions(
+	ctx context.Context,
+	options *ListBalanceTransactionsOptions,
+) iter.Seq2[*BalanceTransaction, error] {
+	u := "v2/balances/primary/transactions"
+
+	return paginate(ctx, bs.client, u, options, func(l *BalanceTransactionsList) ([]*BalanceTransaction, *URL) {
+		return l.Embedded.BalanceTransactions, l.Links.Next
+	})
+}
+
 func (bs *BalancesService) get(ctx context.Context, balance string) (res *Response, b *Balance, err error) {
 	u := fmt.Sprintf("v2/balances/%s", balance)
 
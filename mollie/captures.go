@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -42,21 +43,28 @@ type CaptureAccessTokenFields struct {
 // Capture describes a single capture.
 // Captures are used for payments that have the authorize-then-capture flow.
 type Capture struct {
-	Resource         string        `json:"resource,omitempty"`
-	ID               string        `json:"id,omitempty"`
-	Mode             Mode          `json:"mode,omitempty"`
-	Amount           *Amount       `json:"amount,omitempty"`
-	Status           CaptureStatus `json:"status,omitempty"`
-	SettlementAmount *Amount       `json:"settlementAmount,omitempty"`
-	PaymentID        string        `json:"paymentId,omitempty"`
-	ShipmentID       string        `json:"shipmentId,omitempty"`
-	SettlementID     string        `json:"settlementId,omitempty"`
-	CreatedAt        *time.Time    `json:"createdAt,omitempty"`
-	Metadata         any           `json:"metadata,omitempty"`
-	Links            CaptureLinks  `json:"_links,omitempty"`
+	Resource         string          `json:"resource,omitempty"`
+	ID               string          `json:"id,omitempty"`
+	Mode             Mode            `json:"mode,omitempty"`
+	Amount           *Amount         `json:"amount,omitempty"`
+	Status           CaptureStatus   `json:"status,omitempty"`
+	SettlementAmount *Amount         `json:"settlementAmount,omitempty"`
+	PaymentID        string          `json:"paymentId,omitempty"`
+	ShipmentID       string          `json:"shipmentId,omitempty"`
+	SettlementID     string          `json:"settlementId,omitempty"`
+	CreatedAt        *time.Time      `json:"createdAt,omitempty"`
+	Metadata         any             `json:"metadata,omitempty"`
+	Links            CaptureLinks    `json:"_links,omitempty"`
+	Embedded         CaptureEmbedded `json:"_embedded,omitempty"`
 	CaptureAccessTokenFields
 }
 
+// CaptureEmbedded contains the resources embedded in a capture when
+// requested using the embed option.
+type CaptureEmbedded struct {
+	Payment *Payment `json:"payment,omitempty"`
+}
+
 // CaptureLinks contains relevant links for a capture object.
 type CaptureLinks struct {
 	Self          *URL `json:"self,omitempty"`
@@ -70,7 +78,7 @@ type CaptureLinks struct {
 //
 // See: https://docs.mollie.com/reference/get-capture#embedding-of-related-resources
 type CaptureOptions struct {
-	Embed []EmbedValue `url:"embed,omitempty"`
+	Embed []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // CapturesList describes a list of captures.
@@ -118,10 +126,6 @@ func (cs *CapturesService) Create(ctx context.Context, payment string, capture C
 ) {
 	u := fmt.Sprintf("v2/payments/%s/captures", payment)
 
-	if cs.client.HasAccessToken() && cs.client.config.testing {
-		capture.Testmode = true
-	}
-
 	res, err = cs.client.post(ctx, u, capture, nil)
 	if err != nil {
 		return
@@ -155,3 +159,16 @@ func (cs *CapturesService) List(ctx context.Context, payment string, options *Ca
 
 	return
 }
+
+// All returns an iterator over all captures for a certain payment.
+func (cs *CapturesService) All(
+	ctx context.Context,
+	payment string,
+	options *CaptureOptions,
+) iter.Seq2[*Capture, error] {
+	u := fmt.Sprintf("v2/payments/%s/captures", payment)
+
+	return paginate(ctx, cs.client, u, options, func(l *CapturesList) ([]*Capture, *URL) {
+		return l.Embedded.Captures, l.Links.Next
+	})
+}
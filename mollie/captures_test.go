@@ -8,6 +8,7 @@ import (
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCapturesService_Get(t *testing.T) {
@@ -406,3 +407,26 @@ func TestCapturesService_List(t *testing.T) {
 		})
 	}
 }
+
+func TestCapturesService_GetEmbedded(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg/captures/cpt_mNepDkEtco6ah3QNPUGYH", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "embed=payment")
+		_, _ = w.Write([]byte(testdata.GetCaptureEmbeddedResponse))
+	})
+
+	_, c, err := tClient.Captures.Get(context.Background(), "tr_WDqYK6vllg", "cpt_mNepDkEtco6ah3QNPUGYH", &CaptureOptions{
+		Embed: []EmbedValue{EmbedPayment},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, c.Embedded.Payment)
+	assert.Equal(t, "tr_WDqYK6vllg", c.Embedded.Payment.ID)
+	assert.Equal(t, PaymentStatusPaid, c.Embedded.Payment.Status)
+}
@@ -4,23 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
 // Chargeback describes a forced transaction reversal initiated by the cardholder's bank.
 type Chargeback struct {
-	Resource         string            `json:"resource,omitempty"`
-	ID               string            `json:"id,omitempty"`
-	PaymentID        string            `json:"paymentId,omitempty"`
-	Amount           *Amount           `json:"amount,omitempty"`
-	SettlementAmount *Amount           `json:"settlementAmount,omitempty"`
-	Reason           *ChargebackReason `json:"reason,omitempty"`
-	CreatedAt        *time.Time        `json:"createdAt,omitempty"`
-	ReversedAt       *time.Time        `json:"reversedAt,omitempty"`
-	Links            ChargebackLinks   `json:"_links,omitempty"`
+	Resource         string             `json:"resource,omitempty"`
+	ID               string             `json:"id,omitempty"`
+	PaymentID        string             `json:"paymentId,omitempty"`
+	Amount           *Amount            `json:"amount,omitempty"`
+	SettlementAmount *Amount            `json:"settlementAmount,omitempty"`
+	Reason           *ChargebackReason  `json:"reason,omitempty"`
+	CreatedAt        *time.Time         `json:"createdAt,omitempty"`
+	ReversedAt       *time.Time         `json:"reversedAt,omitempty"`
+	Links            ChargebackLinks    `json:"_links,omitempty"`
+	Embedded         ChargebackEmbedded `json:"_embedded,omitempty"`
 	ChargebackAccessTokenFields
 }
 
+// ChargebackEmbedded contains the resources embedded in a chargeback when
+// requested using the embed option.
+type ChargebackEmbedded struct {
+	Payment *Payment `json:"payment,omitempty"`
+}
+
 // ChargebackReason describes the reason for the chargeback as given by the bank.
 type ChargebackReason struct {
 	Code        string `json:"code,omitempty"`
@@ -45,7 +53,7 @@ type ChargebackLinks struct {
 // ChargebackOptions describes chargeback endpoint valid query string parameters.
 type ChargebackOptions struct {
 	Include []IncludeValue `url:"include,omitempty"`
-	Embed   []EmbedValue   `url:"embed,omitempty"`
+	Embed   []EmbedValue   `url:"embed,omitempty,comma"`
 }
 
 // ListChargebacksOptions describes list chargebacks endpoint valid query string parameters.
@@ -53,7 +61,7 @@ type ListChargebacksOptions struct {
 	From      string         `url:"from,omitempty"`
 	Limit     int            `url:"limit,omitempty"`
 	Include   []IncludeValue `url:"include,omitempty"`
-	Embed     []EmbedValue   `url:"embed,omitempty"`
+	Embed     []EmbedValue   `url:"embed,omitempty,comma"`
 	ProfileID string         `url:"profileId,omitempty"`
 }
 
@@ -103,6 +111,13 @@ func (cs *ChargebacksService) List(ctx context.Context, options *ListChargebacks
 	return cs.list(ctx, "v2/chargebacks", options)
 }
 
+// All returns an iterator over all the chargebacks created.
+func (cs *ChargebacksService) All(ctx context.Context, options *ListChargebacksOptions) iter.Seq2[*Chargeback, error] {
+	return paginate(ctx, cs.client, "v2/chargebacks", options, func(l *ChargebacksList) ([]*Chargeback, *URL) {
+		return l.Embedded.Chargebacks, l.Links.Next
+	})
+}
+
 // ListForPayment retrieves a list of chargebacks associated with a single payment.
 //
 // See: https://docs.mollie.com/reference/list-payment-chargebacks
@@ -114,6 +129,19 @@ func (cs *ChargebacksService) ListForPayment(ctx context.Context, payment string
 	return cs.list(ctx, fmt.Sprintf("v2/payments/%s/chargebacks", payment), options)
 }
 
+// AllForPayment returns an iterator over all the chargebacks for the given payment.
+func (cs *ChargebacksService) AllForPayment(
+	ctx context.Context,
+	payment string,
+	options *ListChargebacksOptions,
+) iter.Seq2[*Chargeback, error] {
+	u := fmt.Sprintf("v2/payments/%s/chargebacks", payment)
+
+	return paginate(ctx, cs.client, u, options, func(l *ChargebacksList) ([]*Chargeback, *URL) {
+		return l.Embedded.Chargebacks, l.Links.Next
+	})
+}
+
 // encapsulates the shared list methods logic.
 func (cs *ChargebacksService) list(ctx context.Context, uri string, options interface{}) (
 	res *Response,
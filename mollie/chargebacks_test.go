@@ -9,6 +9,7 @@ import (
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestChargebacksService_Get(t *testing.T) {
@@ -368,3 +369,25 @@ func TestChargebacksService_ListForPayment(t *testing.T) {
 		})
 	}
 }
+
+func TestChargebacksService_GetEmbedded(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg/chargebacks/chb_n9z0tp", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "embed=payment")
+		_, _ = w.Write([]byte(testdata.GetChargebackEmbeddedResponse))
+	})
+
+	_, cb, err := tClient.Chargebacks.Get(context.Background(), "tr_WDqYK6vllg", "chb_n9z0tp", &ChargebackOptions{
+		Embed: []EmbedValue{EmbedPayment},
+	})
+	require.Nil(t, err)
+	require.NotNil(t, cb.Embedded.Payment)
+	assert.Equal(t, "tr_WDqYK6vllg", cb.Embedded.Payment.ID)
+}
@@ -0,0 +1,268 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Default values used by the CircuitBreaker.
+const (
+	DefaultCircuitFailureThreshold = 5
+	DefaultCircuitOpenTimeout      = 30 * time.Second
+	DefaultCircuitHalfOpenProbes   = 1
+)
+
+// ErrCircuitOpen is matched by the errors returned for requests rejected
+// by an open circuit.
+var ErrCircuitOpen = errors.New("mollie: circuit open")
+
+// CircuitOpenError is returned without sending the request when the circuit
+// of its endpoint group is open.
+type CircuitOpenError struct {
+	Group EndpointGroup
+	// RetryAfter is the time left before the circuit lets probe requests
+	// through, zero when the probes are already in flight.
+	RetryAfter time.Duration
+}
+
+// Error interface compliance.
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("%s: %s, retry after %s", ErrCircuitOpen, e.Group, e.RetryAfter)
+}
+
+// Is enables the usage of errors.Is with ErrCircuitOpen.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrCircuitOpen
+}
+
+// CircuitState describes the state of the circuit of an endpoint group.
+type CircuitState string
+
+// Possible circuit states.
+const (
+	CircuitClosed   CircuitState = "closed"
+	CircuitOpen     CircuitState = "open"
+	CircuitHalfOpen CircuitState = "half-open"
+)
+
+// CircuitSettings configures when a circuit opens and how it recovers.
+type CircuitSettings struct {
+	// FailureThreshold is the number of consecutive failures opening the circuit.
+	FailureThreshold int
+	// OpenTimeout is the time an open circuit rejects requests before
+	// letting probe requests through.
+	OpenTimeout time.Duration
+	// HalfOpenProbes is the number of probe requests sent while half-open,
+	// the circuit closes once all of them succeed.
+	HalfOpenProbes int
+}
+
+func (s CircuitSettings) withDefaults() CircuitSettings {
+	if s.FailureThreshold < 1 {
+		s.FailureThreshold = DefaultCircuitFailureThreshold
+	}
+
+	if s.OpenTimeout <= 0 {
+		s.OpenTimeout = DefaultCircuitOpenTimeout
+	}
+
+	if s.HalfOpenProbes < 1 {
+		s.HalfOpenProbes = DefaultCircuitHalfOpenProbes
+	}
+
+	return s
+}
+
+// CircuitBreaker rejects the requests to an endpoint group after repeated
+// failures, so callers fail fast while Mollie is degraded instead of
+// waiting for timeouts.
+//
+// Every endpoint group has its own circuit. Transport errors and 500, 502,
+// 503 and 504 responses count as failures, once FailureThreshold consecutive
+// failures happen the circuit opens and requests fail with a
+// *CircuitOpenError. After OpenTimeout the circuit is half-open and lets
+// HalfOpenProbes requests through: it closes when they all succeed and
+// opens again on the first failure.
+//
+// A single CircuitBreaker can be shared between multiple clients.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	settings CircuitSettings
+	groups   map[EndpointGroup]CircuitSettings
+	circuits map[EndpointGroup]*circuit
+	now      func() time.Time
+}
+
+type circuit struct {
+	state     CircuitState
+	failures  int
+	openedAt  time.Time
+	probes    int
+	successes int
+}
+
+// NewCircuitBreaker returns a CircuitBreaker applying settings to every
+// endpoint group, zero values are replaced by the defaults.
+func NewCircuitBreaker(settings CircuitSettings) *CircuitBreaker {
+	return &CircuitBreaker{
+		settings: settings.withDefaults(),
+		groups:   make(map[EndpointGroup]CircuitSettings),
+		circuits: make(map[EndpointGroup]*circuit),
+		now:      time.Now,
+	}
+}
+
+// SetSettings configures the circuit of an endpoint group, e.g. a lower
+// failure threshold for payments.
+func (cb *CircuitBreaker) SetSettings(group EndpointGroup, settings CircuitSettings) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.groups[group] = settings.withDefaults()
+}
+
+// State returns the current state of the circuit of an endpoint group.
+func (cb *CircuitBreaker) State(group EndpointGroup) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c, ok := cb.circuits[group]
+	if !ok {
+		return CircuitClosed
+	}
+
+	if c.state == CircuitOpen && cb.now().Sub(c.openedAt) >= cb.settingsFor(group).OpenTimeout {
+		return CircuitHalfOpen
+	}
+
+	return c.state
+}
+
+// Allow reports if the request can be sent, it returns a *CircuitOpenError
+// when the circuit of its endpoint group is open.
+//
+// Every allowed request must be followed by a call to Observe.
+func (cb *CircuitBreaker) Allow(req *http.Request) error {
+	group := EndpointGroupOf(req.URL.Path)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.circuit(group)
+	s := cb.settingsFor(group)
+
+	if c.state == CircuitOpen {
+		wait := s.OpenTimeout - cb.now().Sub(c.openedAt)
+		if wait > 0 {
+			return &CircuitOpenError{Group: group, RetryAfter: wait}
+		}
+
+		c.state, c.probes, c.successes = CircuitHalfOpen, 0, 0
+	}
+
+	if c.state == CircuitHalfOpen {
+		if c.probes >= s.HalfOpenProbes {
+			return &CircuitOpenError{Group: group}
+		}
+
+		c.probes++
+	}
+
+	return nil
+}
+
+// release gives back the probe slot of a request allowed by Allow but
+// never sent.
+func (cb *CircuitBreaker) release(req *http.Request) {
+	group := EndpointGroupOf(req.URL.Path)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	cb.circuit(group).release()
+}
+
+// Observe records the outcome of a request allowed by Allow.
+func (cb *CircuitBreaker) Observe(req *http.Request, res *Response, err error) {
+	group := EndpointGroupOf(req.URL.Path)
+	failed := circuitFailure(res, err)
+
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+
+	c := cb.circuit(group)
+	s := cb.settingsFor(group)
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		// the caller gave up, the probe slot is released without an outcome.
+		c.release()
+
+		return
+	}
+
+	switch c.state {
+	case CircuitClosed:
+		if !failed {
+			c.failures = 0
+
+			return
+		}
+
+		c.failures++
+		if c.failures >= s.FailureThreshold {
+			c.state, c.openedAt = CircuitOpen, cb.now()
+		}
+	case CircuitHalfOpen:
+		if failed {
+			c.state, c.openedAt = CircuitOpen, cb.now()
+
+			return
+		}
+
+		c.successes++
+		if c.successes >= s.HalfOpenProbes {
+			*c = circuit{state: CircuitClosed}
+		}
+	case CircuitOpen:
+		// outcome of a request sent before the circuit opened.
+	}
+}
+
+func (cb *CircuitBreaker) circuit(group EndpointGroup) *circuit {
+	c, ok := cb.circuits[group]
+	if !ok {
+		c = &circuit{state: CircuitClosed}
+		cb.circuits[group] = c
+	}
+
+	return c
+}
+
+func (cb *CircuitBreaker) settingsFor(group EndpointGroup) CircuitSettings {
+	if s, ok := cb.groups[group]; ok {
+		return s
+	}
+
+	return cb.settings
+}
+
+// circuitFailure reports if the outcome of a request means Mollie is
+// degraded, throttled requests and canceled contexts are not failures.
+func circuitFailure(res *Response, err error) bool {
+	if res != nil && res.Response != nil && res.StatusCode == http.StatusTooManyRequests {
+		return false
+	}
+
+	return retryableFailure(res, err)
+}
+
+// release frees a probe slot of a half-open circuit.
+func (c *circuit) release() {
+	if c.state == CircuitHalfOpen && c.probes > 0 {
+		c.probes--
+	}
+}
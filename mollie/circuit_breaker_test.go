@@ -0,0 +1,180 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCircuitRequest(t *testing.T, path string) *http.Request {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.mollie.com"+path, nil)
+	require.Nil(t, err)
+
+	return req
+}
+
+func statusResponse(code int) *Response {
+	return &Response{Response: &http.Response{StatusCode: code}}
+}
+
+func TestCircuitBreaker_States(t *testing.T) {
+	now := time.Now()
+	cb := NewCircuitBreaker(CircuitSettings{FailureThreshold: 3, OpenTimeout: time.Minute, HalfOpenProbes: 2})
+	cb.now = func() time.Time { return now }
+
+	payments := newCircuitRequest(t, "/v2/payments/tr_WDqYK6vllg")
+	refunds := newCircuitRequest(t, "/v2/payments/tr_WDqYK6vllg/refunds")
+
+	observe := func(req *http.Request, res *Response, err error) {
+		t.Helper()
+		require.Nil(t, cb.Allow(req))
+		cb.Observe(req, res, err)
+	}
+
+	observe(payments, statusResponse(http.StatusServiceUnavailable), errors.New("503"))
+	observe(payments, statusResponse(http.StatusServiceUnavailable), errors.New("503"))
+	observe(payments, statusResponse(http.StatusOK), nil)
+	assert.Equal(t, CircuitClosed, cb.State("payments"), "a success resets the failures")
+
+	observe(payments, statusResponse(http.StatusNotFound), errors.New("404"))
+	observe(payments, statusResponse(http.StatusTooManyRequests), errors.New("429"))
+	observe(payments, nil, context.Canceled)
+	assert.Equal(t, CircuitClosed, cb.State("payments"), "client errors and throttling are not failures")
+
+	for range 3 {
+		observe(payments, nil, errors.New("http_error: connection refused"))
+	}
+
+	require.Equal(t, CircuitOpen, cb.State("payments"))
+	assert.Equal(t, CircuitClosed, cb.State("refunds"))
+	assert.Nil(t, cb.Allow(refunds), "every endpoint group has its own circuit")
+
+	now = now.Add(20 * time.Second)
+	err := cb.Allow(payments)
+	require.ErrorIs(t, err, ErrCircuitOpen)
+
+	var coe *CircuitOpenError
+	require.True(t, errors.As(err, &coe))
+	assert.Equal(t, EndpointGroup("payments"), coe.Group)
+	assert.Equal(t, 40*time.Second, coe.RetryAfter)
+
+	now = now.Add(40 * time.Second)
+	assert.Equal(t, CircuitHalfOpen, cb.State("payments"))
+	require.Nil(t, cb.Allow(payments))
+	require.Nil(t, cb.Allow(payments))
+	assert.ErrorIs(t, cb.Allow(payments), ErrCircuitOpen, "only the probes are let through")
+
+	cb.Observe(payments, statusResponse(http.StatusOK), nil)
+	cb.Observe(payments, statusResponse(http.StatusBadGateway), errors.New("502"))
+	assert.Equal(t, CircuitOpen, cb.State("payments"), "a failed probe opens the circuit again")
+
+	now = now.Add(time.Minute)
+	observe(payments, statusResponse(http.StatusOK), nil)
+	observe(payments, statusResponse(http.StatusOK), nil)
+	assert.Equal(t, CircuitClosed, cb.State("payments"))
+}
+
+func TestCircuitBreaker_SetSettings(t *testing.T) {
+	cb := NewCircuitBreaker(CircuitSettings{})
+	cb.SetSettings("balances", CircuitSettings{FailureThreshold: 1})
+
+	balances := newCircuitRequest(t, "/v2/balances/primary")
+	require.Nil(t, cb.Allow(balances))
+	cb.Observe(balances, nil, errors.New("http_error: timeout"))
+	assert.Equal(t, CircuitOpen, cb.State("balances"))
+
+	payments := newCircuitRequest(t, "/v2/payments")
+	for range DefaultCircuitFailureThreshold - 1 {
+		require.Nil(t, cb.Allow(payments))
+		cb.Observe(payments, nil, errors.New("http_error: timeout"))
+	}
+
+	assert.Equal(t, CircuitClosed, cb.State("payments"))
+}
+
+func TestClient_Do_CircuitBreaker(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tConf.SetCircuitBreaker(NewCircuitBreaker(CircuitSettings{FailureThreshold: 2}))
+	tConf.SetRetryPolicy(&BackoffPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+	calls := 0
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg", func(w http.ResponseWriter, _ *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	tMux.HandleFunc("/v2/customers/cst_kEn1PlbGa", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"cst_kEn1PlbGa"}`))
+	})
+
+	_, _, err := tClient.Payments.Get(context.Background(), "tr_WDqYK6vllg", nil)
+	assert.ErrorIs(t, err, ErrCircuitOpen, "the retries stop once the circuit opens")
+	assert.Equal(t, 2, calls)
+
+	_, _, err = tClient.Payments.Get(context.Background(), "tr_WDqYK6vllg", nil)
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, calls)
+
+	_, _, err = tClient.Customers.Get(context.Background(), "cst_kEn1PlbGa")
+	assert.Nil(t, err)
+}
+
+func TestClient_Do_OpenCircuitSkipsRateLimiter(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	rl := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1})
+	cb := NewCircuitBreaker(CircuitSettings{FailureThreshold: 1, OpenTimeout: time.Hour})
+
+	tConf.SetRateLimiter(rl)
+	tConf.SetCircuitBreaker(cb)
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, _, err := tClient.Payments.Get(context.Background(), "tr_WDqYK6vllg", nil)
+	require.NotNil(t, err)
+	require.Equal(t, CircuitOpen, cb.State("payments"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err = tClient.Payments.Get(ctx, "tr_WDqYK6vllg", nil)
+
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+	assert.InDelta(t, 0, rl.global.tokens, 0.01, "the open circuit does not consume rate limit budget")
+}
+
+func TestCircuitBreaker_ReleasesProbeWhenRateLimited(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cb := NewCircuitBreaker(CircuitSettings{FailureThreshold: 1, OpenTimeout: time.Second, HalfOpenProbes: 1})
+	cb.now = func() time.Time { return now }
+
+	req, _ := http.NewRequest(http.MethodGet, "https://api.mollie.com/v2/payments/tr_1", nil)
+
+	require.Nil(t, cb.Allow(req))
+	cb.Observe(req, nil, errors.New("connection refused"))
+
+	now = now.Add(2 * time.Second)
+	require.Nil(t, cb.Allow(req))
+	assert.ErrorIs(t, cb.Allow(req), ErrCircuitOpen)
+
+	cb.release(req)
+	assert.Nil(t, cb.Allow(req), "the probe slot is available again")
+}
@@ -0,0 +1,147 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ResourceKind describes the type of resource identified by a Mollie ID.
+type ResourceKind string
+
+// Resource kinds that can be inferred from the prefix of an ID.
+const (
+	UnknownResource      ResourceKind = ""
+	PaymentResource      ResourceKind = "payment"
+	OrderResource        ResourceKind = "order"
+	SubscriptionResource ResourceKind = "subscription"
+	RefundResource       ResourceKind = "refund"
+	PaymentLinkResource  ResourceKind = "payment-link"
+	CustomerResource     ResourceKind = "customer"
+	MandateResource      ResourceKind = "mandate"
+	ChargebackResource   ResourceKind = "chargeback"
+	CaptureResource      ResourceKind = "capture"
+	ShipmentResource     ResourceKind = "shipment"
+	SettlementResource   ResourceKind = "settlement"
+	ProfileResource      ResourceKind = "profile"
+	SalesInvoiceResource ResourceKind = "sales-invoice"
+)
+
+var resourcePrefixes = map[string]ResourceKind{
+	"tr":      PaymentResource,
+	"ord":     OrderResource,
+	"sub":     SubscriptionResource,
+	"re":      RefundResource,
+	"pl":      PaymentLinkResource,
+	"cst":     CustomerResource,
+	"mdt":     MandateResource,
+	"chb":     ChargebackResource,
+	"cpt":     CaptureResource,
+	"shp":     ShipmentResource,
+	"stl":     SettlementResource,
+	"pfl":     ProfileResource,
+	"invoice": SalesInvoiceResource,
+}
+
+// ResourceKindFromID infers the kind of resource from the prefix of its ID,
+// e.g. tr_WDqYK6vllg is a payment.
+func ResourceKindFromID(id string) ResourceKind {
+	prefix, _, ok := strings.Cut(id, "_")
+	if !ok {
+		return UnknownResource
+	}
+
+	return resourcePrefixes[prefix]
+}
+
+// ClassicWebhookHandler handles the webhooks sent to the WebhookURL of payments,
+// orders and payment links, which only contain the ID of the updated resource.
+//
+// The resource is fetched from the API using the client, this is the way
+// Mollie recommends to verify classic webhook calls, and passed to the
+// matching typed callback.
+//
+// Subscriptions and refunds do not trigger calls with their own IDs: Mollie
+// calls the webhook with the ID of the related payment instead. IDs that can
+// not be fetched on their own are passed to OnOther.
+//
+// See: https://docs.mollie.com/reference/webhooks
+type ClassicWebhookHandler struct {
+	Client *Client
+
+	OnPayment     func(ctx context.Context, p *Payment) error
+	OnOrder       func(ctx context.Context, o *Order) error
+	OnPaymentLink func(ctx context.Context, pl *PaymentLink) error
+	OnOther       func(ctx context.Context, kind ResourceKind, id string) error
+}
+
+var errUnhandledWebhookResource = errors.New("no callback registered for the webhook resource")
+
+// ServeHTTP implements the http.Handler interface.
+//
+// It answers with 200 OK when the callback succeeds, 400 Bad Request when
+// the ID is missing or no callback can handle it, 404 Not Found when Mollie
+// does not know the resource and 500 Internal Server Error on any other
+// failure, so that Mollie retries the call later.
+func (h *ClassicWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	id := strings.TrimSpace(r.PostFormValue("id"))
+	if id == "" {
+		w.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	err := h.Resolve(r.Context(), id)
+
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusOK)
+	case errors.Is(err, errUnhandledWebhookResource):
+		w.WriteHeader(http.StatusBadRequest)
+	case errors.Is(err, ErrNotFound):
+		w.WriteHeader(http.StatusNotFound)
+	default:
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+// Resolve fetches the resource identified by id and passes it to the
+// matching callback.
+func (h *ClassicWebhookHandler) Resolve(ctx context.Context, id string) error {
+	kind := ResourceKindFromID(id)
+
+	switch {
+	case kind == PaymentResource && h.OnPayment != nil:
+		_, p, err := h.Client.Payments.Get(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+
+		return h.OnPayment(ctx, p)
+	case kind == OrderResource && h.OnOrder != nil:
+		_, o, err := h.Client.Orders.Get(ctx, id, nil)
+		if err != nil {
+			return err
+		}
+
+		return h.OnOrder(ctx, o)
+	case kind == PaymentLinkResource && h.OnPaymentLink != nil:
+		_, pl, err := h.Client.PaymentLinks.Get(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return h.OnPaymentLink(ctx, pl)
+	case h.OnOther != nil:
+		return h.OnOther(ctx, kind, id)
+	default:
+		return errUnhandledWebhookResource
+	}
+}
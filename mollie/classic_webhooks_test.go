@@ -0,0 +1,217 @@
+package mollie
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResourceKindFromID(t *testing.T) {
+	cases := []struct {
+		id   string
+		want ResourceKind
+	}{
+		{"tr_WDqYK6vllg", PaymentResource},
+		{"ord_kEn1PlbGa", OrderResource},
+		{"sub_rVKGtNd6s3", SubscriptionResource},
+		{"re_4qqhO89gsT", RefundResource},
+		{"pl_4Y0eZitmBnQ6IDoMqZQKh", PaymentLinkResource},
+		{"cst_8wmqcHMN4U", CustomerResource},
+		{"invoice_4Y0eZitmBnQ6IDoMqZQKh", SalesInvoiceResource},
+		{"xyz_123", UnknownResource},
+		{"no-prefix", UnknownResource},
+		{"", UnknownResource},
+	}
+
+	for _, c := range cases {
+		t.Run(c.id, func(t *testing.T) {
+			assert.Equal(t, c.want, ResourceKindFromID(c.id))
+		})
+	}
+}
+
+func TestClassicWebhookHandler_ServeHTTP(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	cases := []struct {
+		name    string
+		method  string
+		id      string
+		path    string
+		handler http.HandlerFunc
+		cbErr   error
+		want    int
+		called  string
+	}{
+		{
+			"payments are fetched and passed to the callback",
+			http.MethodPost,
+			"tr_WDqYK6vllg",
+			"/v2/payments/tr_WDqYK6vllg",
+			func(w http.ResponseWriter, r *http.Request) {
+				testMethod(t, r, "GET")
+				_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+			},
+			nil,
+			http.StatusOK,
+			"payment",
+		},
+		{
+			"orders are fetched and passed to the callback",
+			http.MethodPost,
+			"ord_kEn1PlbGa",
+			"/v2/orders/ord_kEn1PlbGa",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testdata.GetOrderResponse))
+			},
+			nil,
+			http.StatusOK,
+			"order",
+		},
+		{
+			"payment links are fetched and passed to the callback",
+			http.MethodPost,
+			"pl_4Y0eZitmBnQ6IDoMqZQKh",
+			"/v2/payment-links/pl_4Y0eZitmBnQ6IDoMqZQKh",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testdata.GetPaymentLinkResponse))
+			},
+			nil,
+			http.StatusOK,
+			"payment-link",
+		},
+		{
+			"unresolvable ids are passed to the other callback",
+			http.MethodPost,
+			"re_4qqhO89gsT",
+			"/unused",
+			nil,
+			nil,
+			http.StatusOK,
+			"other:refund",
+		},
+		{
+			"callback errors are reported to mollie",
+			http.MethodPost,
+			"tr_WDqYK6vllg",
+			"/v2/payments/tr_WDqYK6vllg",
+			func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+			},
+			fmt.Errorf("database is down"),
+			http.StatusInternalServerError,
+			"payment",
+		},
+		{
+			"unknown resources return not found",
+			http.MethodPost,
+			"tr_I_dont_exist",
+			"/v2/payments/tr_I_dont_exist",
+			func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+				_, _ = w.Write([]byte(testdata.NotFoundErrorResponse))
+			},
+			nil,
+			http.StatusNotFound,
+			"",
+		},
+		{
+			"api errors are reported to mollie",
+			http.MethodPost,
+			"tr_WDqYK6vllg",
+			"/v2/payments/tr_WDqYK6vllg",
+			errorHandler,
+			nil,
+			http.StatusInternalServerError,
+			"",
+		},
+		{
+			"missing ids are rejected",
+			http.MethodPost,
+			"",
+			"/unused",
+			nil,
+			nil,
+			http.StatusBadRequest,
+			"",
+		},
+		{
+			"non post requests are rejected",
+			http.MethodGet,
+			"tr_WDqYK6vllg",
+			"/unused",
+			nil,
+			nil,
+			http.StatusMethodNotAllowed,
+			"",
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		t.Run(c.name, func(t *testing.T) {
+			if c.handler != nil {
+				tMux.HandleFunc(c.path, c.handler)
+			}
+
+			var called string
+
+			h := &ClassicWebhookHandler{
+				Client: tClient,
+				OnPayment: func(ctx context.Context, p *Payment) error {
+					called = "payment"
+
+					return c.cbErr
+				},
+				OnOrder: func(ctx context.Context, o *Order) error {
+					called = "order"
+
+					return c.cbErr
+				},
+				OnPaymentLink: func(ctx context.Context, pl *PaymentLink) error {
+					called = "payment-link"
+
+					return c.cbErr
+				},
+				OnOther: func(ctx context.Context, kind ResourceKind, id string) error {
+					called = "other:" + string(kind)
+
+					return c.cbErr
+				},
+			}
+
+			form := url.Values{"id": {c.id}}
+			req := httptest.NewRequest(c.method, "/webhook", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, c.want, rec.Code)
+			assert.Equal(t, c.called, called)
+		})
+	}
+}
+
+func TestClassicWebhookHandler_Unhandled(t *testing.T) {
+	h := &ClassicWebhookHandler{}
+
+	form := url.Values{"id": {"tr_WDqYK6vllg"}}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
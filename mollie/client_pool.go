@@ -0,0 +1,187 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrTokenNotFound is returned by a TokenStore when it has no token for
+// an organization.
+var ErrTokenNotFound = errors.New("token not found")
+
+// TenantToken contains the OAuth token granted by a connected organization
+// and the defaults used for the requests made on its behalf.
+type TenantToken struct {
+	*oauth2.Token
+	// ProfileID is sent as profileId to the endpoints requiring one, e.g.
+	// when creating or listing payments, unless the request already
+	// contains one.
+	ProfileID string `json:"profileId,omitempty"`
+	// Testmode sends every request of the organization in test mode.
+	Testmode bool `json:"testmode,omitempty"`
+}
+
+// TokenStore persists the tokens of the connected organizations.
+//
+// Implementations must be safe for concurrent use.
+type TokenStore interface {
+	// Token returns the token of an organization, or ErrTokenNotFound.
+	Token(ctx context.Context, orgID string) (*TenantToken, error)
+	// SaveToken stores the token of an organization, it is called every
+	// time an access token is refreshed.
+	SaveToken(ctx context.Context, orgID string, tkn *TenantToken) error
+}
+
+// MemoryTokenStore is a TokenStore keeping the tokens in memory.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]TenantToken
+}
+
+// NewMemoryTokenStore returns an empty MemoryTokenStore.
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: make(map[string]TenantToken)}
+}
+
+// Token returns a copy of the token of an organization.
+func (s *MemoryTokenStore) Token(_ context.Context, orgID string) (*TenantToken, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tkn, ok := s.tokens[orgID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, orgID)
+	}
+
+	return &tkn, nil
+}
+
+// SaveToken stores a copy of the token of an organization.
+func (s *MemoryTokenStore) SaveToken(_ context.Context, orgID string, tkn *TenantToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.tokens[orgID] = *tkn
+
+	return nil
+}
+
+// ClientPool hands out clients acting on behalf of connected organizations.
+//
+// All the clients of a pool share the http.Client, the Config (and with it
+// the retry policy, rate limiter and logger) and the middleware of the base
+// client, only the OAuth token and the request defaults differ.
+type ClientPool struct {
+	base  *Client
+	oauth *oauth2.Config
+	store TokenStore
+
+	mu      sync.Mutex
+	clients map[string]*Client
+}
+
+// NewClientPool returns a pool creating clients based on base, the tokens
+// of the organizations are read from store and refreshed using conf.
+func NewClientPool(base *Client, conf *oauth2.Config, store TokenStore) *ClientPool {
+	return &ClientPool{
+		base:    base,
+		oauth:   conf,
+		store:   store,
+		clients: make(map[string]*Client),
+	}
+}
+
+// ForOrganization returns the client of a connected organization, creating
+// it from the stored token the first time it is requested.
+func (p *ClientPool) ForOrganization(ctx context.Context, orgID string) (*Client, error) {
+	p.mu.Lock()
+	c, ok := p.clients[orgID]
+	p.mu.Unlock()
+
+	if ok {
+		return c, nil
+	}
+
+	tkn, err := p.store.Token(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	if tkn.Token == nil {
+		return nil, fmt.Errorf("%w: %s", ErrTokenNotFound, orgID)
+	}
+
+	c = p.base.clone()
+	c.defaults = requestDefaults{profileID: tkn.ProfileID, testmode: tkn.Testmode}
+	c.SetAuthProvider(NewTokenSourceProvider(&storingTokenSource{
+		ts:     p.oauth.TokenSource(context.WithoutCancel(ctx), tkn.Token),
+		store:  p.store,
+		orgID:  orgID,
+		tenant: *tkn,
+	}))
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	// another goroutine may have created the client in the meantime.
+	if existing, ok := p.clients[orgID]; ok {
+		return existing, nil
+	}
+
+	p.clients[orgID] = c
+
+	return c, nil
+}
+
+// Evict removes the client of an organization from the pool, e.g. after
+// its token was revoked. The next call to ForOrganization reads the token
+// from the store again.
+func (p *ClientPool) Evict(orgID string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.clients, orgID)
+}
+
+// storingTokenSource saves refreshed tokens in the token store.
+type storingTokenSource struct {
+	ts     oauth2.TokenSource
+	store  TokenStore
+	orgID  string
+	tenant TenantToken
+}
+
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	tkn, err := s.ts.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if tkn.AccessToken == s.tenant.AccessToken {
+		return tkn, nil
+	}
+
+	s.tenant.Token = tkn
+	if err := s.store.SaveToken(context.Background(), s.orgID, &s.tenant); err != nil {
+		return nil, fmt.Errorf("saving refreshed token: %w", err)
+	}
+
+	return tkn, nil
+}
+
+// clone returns a copy of the client sharing its http.Client, Config and
+// middleware.
+func (c *Client) clone() *Client {
+	cp := *c
+
+	u := *c.BaseURL
+	cp.BaseURL = &u
+	cp.middleware = append([]Middleware(nil), c.middleware...)
+	cp.initServices()
+
+	return &cp
+}
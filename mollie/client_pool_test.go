@@ -0,0 +1,153 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func newTestPool(t *testing.T) (*ClientPool, *MemoryTokenStore) {
+	t.Helper()
+
+	tokens := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Nil(t, r.ParseForm())
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"access_refreshed_%s","refresh_token":"%s",`+
+			`"token_type":"bearer","expires_in":3600}`,
+			r.PostForm.Get("refresh_token"), r.PostForm.Get("refresh_token"))
+	}))
+	t.Cleanup(tokens.Close)
+
+	conf := &oauth2.Config{
+		ClientID:     "app_j9Pakf56Ajta6Y65AkdTtAv",
+		ClientSecret: "S6uQtmbd2L6ZYS2FhxHAuEptmPjq6MnW",
+		Endpoint:     oauth2.Endpoint{TokenURL: tokens.URL, AuthStyle: oauth2.AuthStyleInParams},
+	}
+
+	store := NewMemoryTokenStore()
+	require.Nil(t, store.SaveToken(context.Background(), "org_1", &TenantToken{
+		Token:     &oauth2.Token{AccessToken: "access_org_1", RefreshToken: "org_1", Expiry: time.Now().Add(time.Hour)},
+		ProfileID: "pfl_QkEhN94Ba",
+		Testmode:  true,
+	}))
+	require.Nil(t, store.SaveToken(context.Background(), "org_2", &TenantToken{
+		Token: &oauth2.Token{AccessToken: "access_org_2", RefreshToken: "org_2", Expiry: time.Now().Add(-time.Hour)},
+	}))
+
+	return NewClientPool(tClient, conf, store), store
+}
+
+func TestClientPool_ForOrganization(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	pool, _ := newTestPool(t)
+
+	c1, err := pool.ForOrganization(context.Background(), "org_1")
+	require.Nil(t, err)
+	c2, err := pool.ForOrganization(context.Background(), "org_2")
+	require.Nil(t, err)
+
+	again, err := pool.ForOrganization(context.Background(), "org_1")
+	require.Nil(t, err)
+	assert.Same(t, c1, again)
+	assert.NotSame(t, c1, c2)
+
+	assert.Same(t, tClient.client, c1.client)
+	assert.Same(t, tClient.config, c1.config)
+	assert.Same(t, c1, c1.Payments.client)
+	assert.True(t, c1.HasAccessToken())
+
+	_, err = pool.ForOrganization(context.Background(), "org_3")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	pool.Evict("org_1")
+	again, err = pool.ForOrganization(context.Background(), "org_1")
+	require.Nil(t, err)
+	assert.NotSame(t, c1, again)
+}
+
+func TestClientPool_RequestDefaults(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	pool, store := newTestPool(t)
+
+	var (
+		auth  []string
+		query []string
+		body  []map[string]any
+	)
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		auth = append(auth, r.Header.Get(AuthHeader))
+		query = append(query, r.URL.RawQuery)
+
+		if r.Method == http.MethodPost {
+			var m map[string]any
+			b, _ := io.ReadAll(r.Body)
+			require.Nil(t, json.Unmarshal(b, &m))
+			body = append(body, m)
+		}
+
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	c1, err := pool.ForOrganization(context.Background(), "org_1")
+	require.Nil(t, err)
+	c2, err := pool.ForOrganization(context.Background(), "org_2")
+	require.Nil(t, err)
+
+	cp := CreatePayment{Amount: &Amount{Currency: "EUR", Value: "10.00"}, Description: "Order"}
+
+	_, _, err = c1.Payments.Create(context.Background(), cp, nil)
+	require.Nil(t, err)
+
+	cp.ProfileID = "pfl_v9hTwCvYqw"
+	_, _, err = c1.Payments.Create(context.Background(), cp, nil)
+	require.Nil(t, err)
+
+	_, _, err = c1.Payments.List(context.Background(), nil)
+	require.Nil(t, err)
+
+	_, _, err = c2.Payments.List(context.Background(), nil)
+	require.Nil(t, err)
+
+	assert.Equal(t, []string{
+		"Bearer access_org_1",
+		"Bearer access_org_1",
+		"Bearer access_org_1",
+		"Bearer access_refreshed_org_2",
+	}, auth)
+
+	assert.Equal(t, "pfl_QkEhN94Ba", body[0]["profileId"])
+	assert.Equal(t, true, body[0]["testmode"])
+	assert.Equal(t, "pfl_v9hTwCvYqw", body[1]["profileId"])
+	assert.Equal(t, "profileId=pfl_QkEhN94Ba&testmode=true", query[2])
+	// the shared testing config adds testmode for every access token.
+	assert.Equal(t, "testmode=true", query[3])
+
+	saved, err := store.Token(context.Background(), "org_2")
+	require.Nil(t, err)
+	assert.Equal(t, "access_refreshed_org_2", saved.AccessToken)
+
+	// the base client is left untouched.
+	req, err := tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+	assert.Empty(t, req.URL.RawQuery)
+	testHeader(t, req, AuthHeader, "Bearer token_X12b31ggg23")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -81,6 +82,13 @@ func (ps *ClientsService) List(ctx context.Context, opts *ListLinkedClientsOptio
 	return
 }
 
+// All returns an iterator over all the clients linked to the partner account.
+func (ps *ClientsService) All(ctx context.Context, opts *ListLinkedClientsOptions) iter.Seq2[*LinkedClient, error] {
+	return paginate(ctx, ps.client, "v2/clients", opts, func(l *LinkedClientList) ([]*LinkedClient, *URL) {
+		return l.PartnerClients.Clients, l.Links.Next
+	})
+}
+
 // Get retrieves a single client, linked to your partner account, by its ID.
 //
 // See: https://docs.mollie.com/reference/get-client
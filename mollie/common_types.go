@@ -337,6 +337,7 @@ type EmbedValue string
 
 // Valid Embed query string value.
 const (
+	EmbedPayment      EmbedValue = "payment"
 	EmbedPayments     EmbedValue = "payments"
 	EmbedRefunds      EmbedValue = "refunds"
 	EmbedShipments    EmbedValue = "shipments"
@@ -1,10 +1,20 @@
 package mollie
 
+import "log/slog"
+
 // Config contains information that helps during the setup of a new Mollie client.
 type Config struct {
 	testing        bool
 	auth           string
 	reqIdempotency bool
+	retryPolicy    RetryPolicy
+	rateLimiter    *RateLimiter
+	logger         *slog.Logger
+	logBodies      bool
+	validation     bool
+	cache          *ResponseCache
+	circuitBreaker *CircuitBreaker
+	profileID      string
 }
 
 // ToggleTesting enables/disables the test-mode in the current Config.
@@ -33,6 +43,74 @@ func (c *Config) SwitchAuthStrategy(auth string) string {
 	return c.auth
 }
 
+// ToggleValidation enables/disables the validation of the payloads
+// implementing the Validator interface before sending them.
+func (c *Config) ToggleValidation() bool {
+	c.validation = !c.validation
+
+	return c.validation
+}
+
+// SetRetryPolicy changes the policy used to retry failed requests,
+// passing nil disables retries, which is the default.
+//
+// See: BackoffPolicy for the default implementation.
+func (c *Config) SetRetryPolicy(p RetryPolicy) RetryPolicy {
+	c.retryPolicy = p
+
+	return c.retryPolicy
+}
+
+// SetRateLimiter changes the limiter used to throttle outgoing requests,
+// passing nil disables client side rate limiting, which is the default.
+func (c *Config) SetRateLimiter(rl *RateLimiter) *RateLimiter {
+	c.rateLimiter = rl
+
+	return c.rateLimiter
+}
+
+// SetLogger changes the logger used to record the requests sent by the client
+// at debug level, passing nil disables logging, which is the default.
+func (c *Config) SetLogger(l *slog.Logger) *slog.Logger {
+	c.logger = l
+
+	return c.logger
+}
+
+// ToggleBodyLogging enables/disables logging the request and response
+// bodies, sensitive fields like card tokens and consumer accounts are
+// always redacted.
+func (c *Config) ToggleBodyLogging() bool {
+	c.logBodies = !c.logBodies
+
+	return c.logBodies
+}
+
+// SetResponseCache changes the cache serving the GET requests of slow
+// changing resources, passing nil disables caching, which is the default.
+func (c *Config) SetResponseCache(rc *ResponseCache) *ResponseCache {
+	c.cache = rc
+
+	return c.cache
+}
+
+// SetCircuitBreaker changes the breaker rejecting the requests to degraded
+// endpoint groups, passing nil disables it, which is the default.
+func (c *Config) SetCircuitBreaker(cb *CircuitBreaker) *CircuitBreaker {
+	c.circuitBreaker = cb
+
+	return c.circuitBreaker
+}
+
+// SetProfileID changes the profileId sent with organization and OAuth
+// access tokens to the endpoints requiring one, e.g. when creating or
+// listing payments, unless the request already contains one.
+func (c *Config) SetProfileID(id string) string {
+	c.profileID = id
+
+	return c.profileID
+}
+
 /* Configuration init helpers.  */
 
 // NewConfig builds a Mollie configuration object,
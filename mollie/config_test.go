@@ -1,10 +1,13 @@
 package mollie
 
 import (
+	"context"
 	"fmt"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig_ToggleTesting(t *testing.T) {
@@ -165,3 +168,101 @@ func ExampleNewOrgConfig() {
 	)
 	// Output: testing org config, testing: false, req_idempotency: true, token source: MOLLIE_ORG_TOKEN.
 }
+
+func TestConfig_SetRetryPolicy(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.Nil(t, c.retryPolicy)
+
+	p := NewBackoffPolicy(5)
+	assert.Equal(t, p, c.SetRetryPolicy(p))
+	assert.Nil(t, c.SetRetryPolicy(nil))
+}
+
+func TestConfig_SetRateLimiter(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.Nil(t, c.rateLimiter)
+
+	rl := NewRateLimiter(RateLimit{Rate: 1, Burst: 1})
+	assert.Equal(t, rl, c.SetRateLimiter(rl))
+}
+
+func TestConfig_SetLogger(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.Nil(t, c.logger)
+	assert.Equal(t, slog.Default(), c.SetLogger(slog.Default()))
+	assert.False(t, c.logBodies)
+	assert.True(t, c.ToggleBodyLogging())
+}
+
+func TestConfig_ToggleValidation(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.False(t, c.validation)
+	assert.True(t, c.ToggleValidation())
+	assert.False(t, c.ToggleValidation())
+}
+
+func TestConfig_SetResponseCache(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.Nil(t, c.cache)
+
+	rc := NewResponseCache(nil)
+	assert.Equal(t, rc, c.SetResponseCache(rc))
+	assert.Nil(t, c.SetResponseCache(nil))
+}
+
+func TestConfig_SetCircuitBreaker(t *testing.T) {
+	c := NewAPITestingConfig(false)
+
+	assert.Nil(t, c.circuitBreaker)
+
+	cb := NewCircuitBreaker(CircuitSettings{})
+	assert.Equal(t, cb, c.SetCircuitBreaker(cb))
+	assert.Nil(t, c.SetCircuitBreaker(nil))
+}
+
+func TestConfig_SetProfileID(t *testing.T) {
+	c := NewOrgConfig(false)
+
+	assert.Empty(t, c.profileID)
+	assert.Equal(t, "pfl_3RkSN1zuPE", c.SetProfileID("pfl_3RkSN1zuPE"))
+}
+
+func TestClient_ConfigProfileID(t *testing.T) {
+	cases := []struct {
+		name  string
+		token string
+		want  []string
+	}{
+		{"access tokens send the profile", "access_X12b31ggg23", []string{"pfl_3RkSN1zuPE"}},
+		{"api keys select the profile themselves", "test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM", nil},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setEnv()
+			setup()
+			defer teardown()
+			defer unsetEnv()
+
+			tClient.config = NewOrgConfig(false)
+			tClient.config.SetProfileID("pfl_3RkSN1zuPE")
+			require.Nil(t, tClient.WithAuthenticationValue(c.token))
+
+			reqs := recordRequests(t)
+
+			_, _, err := tClient.Payments.List(context.Background(), nil)
+			require.Nil(t, err)
+			_, _, err = tClient.Customers.List(context.Background(), nil)
+			require.Nil(t, err)
+
+			require.Len(t, *reqs, 2)
+			assert.Equal(t, c.want, (*reqs)[0].query["profileId"])
+			assert.NotContains(t, (*reqs)[1].query, "profileId")
+		})
+	}
+}
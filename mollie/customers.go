@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -62,7 +63,7 @@ type CustomersList struct {
 	Embedded struct {
 		Customers []*Customer `json:"customers,omitempty"`
 	} `json:"_embedded,omitempty"`
-	Links PaginationLinks `json:"links,omitempty"`
+	Links PaginationLinks `json:"_links,omitempty"`
 }
 
 // CustomersService operates over the customer resource.
@@ -161,6 +162,13 @@ func (cs *CustomersService) List(ctx context.Context, options *ListCustomersOpti
 	return
 }
 
+// All returns an iterator over all customers created.
+func (cs *CustomersService) All(ctx context.Context, options *ListCustomersOptions) iter.Seq2[*Customer, error] {
+	return paginate(ctx, cs.client, "v2/customers", options, func(l *CustomersList) ([]*Customer, *URL) {
+		return l.Embedded.Customers, l.Links.Next
+	})
+}
+
 // GetPayments retrieves all payments linked to the customer.
 //
 // See: https://docs.mollie.com/reference/list-customer-payments
@@ -183,6 +191,19 @@ func (cs *CustomersService) GetPayments(ctx context.Context, id string, options
 	return
 }
 
+// AllPayments returns an iterator over all payments linked to the customer.
+func (cs *CustomersService) AllPayments(
+	ctx context.Context,
+	id string,
+	options *ListCustomersOptions,
+) iter.Seq2[*Payment, error] {
+	u := fmt.Sprintf("v2/customers/%s/payments", id)
+
+	return paginate(ctx, cs.client, u, options, func(l *PaymentList) ([]*Payment, *URL) {
+		return l.Embedded.Payments, l.Links.Next
+	})
+}
+
 // CreatePayment creates a payment for the customer.
 //
 // See: https://docs.mollie.com/reference/create-customer-payment
@@ -67,10 +67,6 @@ func (s *DelayedRoutingService) Create(ctx context.Context, payment string, dr C
 	r *Route,
 	err error,
 ) {
-	if s.client.HasAccessToken() && s.client.config.testing {
-		dr.TestMode = true
-	}
-
 	u := fmt.Sprintf("/v2/payments/%s/routes", payment)
 
 	res, err = s.client.post(ctx, u, dr, nil)
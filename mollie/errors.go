@@ -1,6 +1,27 @@
 package mollie
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Sentinel errors describing the class of an API failure.
+//
+// They can be used with errors.Is to branch on the failure of
+// any call returning a *BaseError.
+var (
+	ErrNotFound      = errors.New("mollie: resource not found")
+	ErrUnauthorized  = errors.New("mollie: unauthorized request")
+	ErrRateLimited   = errors.New("mollie: rate limit exceeded")
+	ErrUnprocessable = errors.New("mollie: unprocessable entity")
+	ErrServer        = errors.New("mollie: server error")
+)
+
+// RequestIDHeader is the response header carrying the request ID
+// to share with Mollie's support when reporting an issue.
+const RequestIDHeader = "X-Request-Id"
 
 // ErrorLinks container references to common urls
 // returned with errors.
@@ -10,12 +31,17 @@ type ErrorLinks struct {
 
 // BaseError contains the general error structure
 // returned by mollie.
+//
+// RequestID and RetryAfter are populated from the response headers
+// when available.
 type BaseError struct {
-	Status int         `json:"status,omitempty"`
-	Title  string      `json:"title,omitempty"`
-	Detail string      `json:"detail,omitempty"`
-	Field  string      `json:"field,omitempty"`
-	Links  *ErrorLinks `json:"_links,omitempty"`
+	Status     int           `json:"status,omitempty"`
+	Title      string        `json:"title,omitempty"`
+	Detail     string        `json:"detail,omitempty"`
+	Field      string        `json:"field,omitempty"`
+	Links      *ErrorLinks   `json:"_links,omitempty"`
+	RequestID  string        `json:"-"`
+	RetryAfter time.Duration `json:"-"`
 }
 
 // Error interface compliance.
@@ -28,3 +54,27 @@ func (be *BaseError) Error() string {
 
 	return str
 }
+
+// Is reports if the error belongs to the failure class described by target,
+// it enables the usage of errors.Is with the package sentinel errors.
+func (be *BaseError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return be.Status == http.StatusNotFound
+	case ErrUnauthorized:
+		return be.Status == http.StatusUnauthorized
+	case ErrRateLimited:
+		return be.Status == http.StatusTooManyRequests
+	case ErrUnprocessable:
+		return be.Status == http.StatusUnprocessableEntity
+	case ErrServer:
+		return be.Status >= http.StatusInternalServerError
+	default:
+		return false
+	}
+}
+
+// Temporary reports if repeating the request later might succeed.
+func (be *BaseError) Temporary() bool {
+	return be.Status == http.StatusTooManyRequests || be.Status >= http.StatusInternalServerError
+}
@@ -1,7 +1,11 @@
 package mollie
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
 	"testing"
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
@@ -39,3 +43,53 @@ func TestBaseError(t *testing.T) {
 		})
 	}
 }
+
+func TestBaseError_Is(t *testing.T) {
+	cases := []struct {
+		name   string
+		status int
+		target error
+		want   bool
+	}{
+		{"not found", http.StatusNotFound, ErrNotFound, true},
+		{"unauthorized", http.StatusUnauthorized, ErrUnauthorized, true},
+		{"rate limited", http.StatusTooManyRequests, ErrRateLimited, true},
+		{"unprocessable", http.StatusUnprocessableEntity, ErrUnprocessable, true},
+		{"server error", http.StatusServiceUnavailable, ErrServer, true},
+		{"not found is not a server error", http.StatusNotFound, ErrServer, false},
+		{"unknown targets are not matched", http.StatusNotFound, errors.New("not found"), false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := fmt.Errorf("wrapped: %w", &BaseError{Status: c.status})
+
+			assert.Equal(t, c.want, errors.Is(err, c.target))
+		})
+	}
+}
+
+func TestBaseError_Temporary(t *testing.T) {
+	assert.True(t, (&BaseError{Status: http.StatusTooManyRequests}).Temporary())
+	assert.True(t, (&BaseError{Status: http.StatusBadGateway}).Temporary())
+	assert.False(t, (&BaseError{Status: http.StatusUnprocessableEntity}).Temporary())
+}
+
+func TestClient_Do_ReturnsSentinelErrors(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tMux.HandleFunc("/v2/payments/tr_I_dont_exist", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(testdata.NotFoundErrorResponse))
+	})
+
+	_, _, err := tClient.Payments.Get(context.Background(), "tr_I_dont_exist", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	var be *BaseError
+	assert.ErrorAs(t, err, &be)
+	assert.Equal(t, "No payment exists with token tr_I_dont_exist.", be.Detail)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 )
 
 // InvoiceStatus status of the invoice.
@@ -103,3 +104,10 @@ func (is *InvoicesService) List(ctx context.Context, options *ListInvoicesOption
 
 	return
 }
+
+// All returns an iterator over all the invoices.
+func (is *InvoicesService) All(ctx context.Context, options *ListInvoicesOptions) iter.Seq2[*Invoice, error] {
+	return paginate(ctx, is.client, "v2/invoices", options, func(l *InvoicesList) ([]*Invoice, *URL) {
+		return l.Embedded.Invoices, l.Links.Next
+	})
+}
@@ -0,0 +1,80 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"iter"
+)
+
+// paginate returns an iterator over every item of a paginated collection.
+//
+// The first page is requested using uri and opts, every following page is
+// retrieved by following the `_links.next` href returned by Mollie until no
+// next link is present.
+//
+// The iterator stops after yielding an error, the error can come from the
+// context being canceled, from the API, from decoding the page or be
+// ErrUntrustedLink when the next link does not point to the BaseURL.
+func paginate[L, T any](
+	ctx context.Context,
+	c *Client,
+	uri string,
+	opts any,
+	page func(*L) ([]*T, *URL),
+) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		next, options := uri, opts
+
+		for next != "" {
+			if err := ctx.Err(); err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			res, err := c.get(ctx, next, options)
+			if err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			list := new(L)
+			if err := json.Unmarshal(res.content, list); err != nil {
+				yield(nil, err)
+
+				return
+			}
+
+			items, link := page(list)
+			for _, item := range items {
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			// The next link already contains the original query string.
+			next, options = "", nil
+			if link != nil && link.Href != "" {
+				u, err := c.trustedURL(link.Href)
+				if err != nil {
+					yield(nil, err)
+
+					return
+				}
+
+				next = u.String()
+			}
+		}
+	}
+}
+
+// pointers converts a slice of values into a slice of pointers to its elements.
+func pointers[T any](items []T) []*T {
+	ptrs := make([]*T, len(items))
+	for i := range items {
+		ptrs[i] = &items[i]
+	}
+
+	return ptrs
+}
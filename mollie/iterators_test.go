@@ -0,0 +1,187 @@
+package mollie
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentsService_All(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+
+		switch r.URL.Query().Get("from") {
+		case "":
+			testQuery(t, r, "limit=1")
+			fmt.Fprintf(w, `{
+				"count": 2,
+				"_embedded": {"payments": [{"resource": "payment", "id": "tr_1"}, {"resource": "payment", "id": "tr_2"}]},
+				"_links": {"next": {"href": "%s/v2/payments?from=tr_3&limit=1", "type": "application/hal+json"}}
+			}`, tServer.URL)
+		case "tr_3":
+			testQuery(t, r, "from=tr_3&limit=1")
+			_, _ = w.Write([]byte(`{
+				"count": 1,
+				"_embedded": {"payments": [{"resource": "payment", "id": "tr_3"}]},
+				"_links": {"next": null}
+			}`))
+		default:
+			t.Errorf("unexpected page requested: %s", r.URL.String())
+		}
+	})
+
+	var ids []string
+	for p, err := range tClient.Payments.All(context.Background(), &ListPaymentsOptions{Limit: 1}) {
+		require.Nil(t, err)
+		ids = append(ids, p.ID)
+	}
+
+	assert.Equal(t, []string{"tr_1", "tr_2", "tr_3"}, ids)
+}
+
+func TestPaymentsService_All_StopsEarly(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	calls := 0
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+	})
+
+	for p, err := range tClient.Payments.All(context.Background(), nil) {
+		require.Nil(t, err)
+		require.NotNil(t, p)
+
+		break
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestPaymentsService_All_UntrustedNextLink(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	calls := 0
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		_, _ = w.Write([]byte(`{
+			"count": 1,
+			"_embedded": {"payments": [{"resource": "payment", "id": "tr_1"}]},
+			"_links": {"next": {"href": "https://attacker.example/v2/payments?from=tr_2", "type": "application/hal+json"}}
+		}`))
+	})
+
+	var (
+		ids  []string
+		errs []error
+	)
+
+	for p, err := range tClient.Payments.All(context.Background(), nil) {
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		ids = append(ids, p.ID)
+	}
+
+	assert.Equal(t, []string{"tr_1"}, ids)
+	require.Len(t, errs, 1)
+	assert.ErrorIs(t, errs[0], ErrUntrustedLink)
+	assert.Equal(t, 1, calls)
+}
+
+func TestPaymentsService_All_Errors(t *testing.T) {
+	setEnv()
+	defer unsetEnv()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cases := []struct {
+		name    string
+		ctx     context.Context
+		err     error
+		handler http.HandlerFunc
+	}{
+		{
+			"iterator yields the api error and stops",
+			context.Background(),
+			fmt.Errorf("500 Internal Server Error: An internal server error occurred while processing your request"),
+			errorHandler,
+		},
+		{
+			"iterator yields the decoding error and stops",
+			context.Background(),
+			fmt.Errorf("invalid character 'h' looking for beginning of object key string"),
+			encodingHandler,
+		},
+		{
+			"iterator honours context cancellation",
+			canceled,
+			context.Canceled,
+			func(w http.ResponseWriter, r *http.Request) {
+				t.Error("no request should be sent with a canceled context")
+			},
+		},
+	}
+
+	for _, c := range cases {
+		setup()
+		defer teardown()
+
+		t.Run(c.name, func(t *testing.T) {
+			tMux.HandleFunc("/v2/payments", c.handler)
+
+			var errs []error
+			for p, err := range tClient.Payments.All(c.ctx, nil) {
+				assert.Nil(t, p)
+				errs = append(errs, err)
+			}
+
+			require.Len(t, errs, 1)
+			assert.EqualError(t, errs[0], c.err.Error())
+		})
+	}
+}
+
+func TestSalesInvoicesService_All(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tMux.HandleFunc("/v2/sales-invoices", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(`{
+			"count": 2,
+			"_embedded": {"sales_invoices": [{"id": "invoice_1"}, {"id": "invoice_2"}]},
+			"_links": {"next": null}
+		}`))
+	})
+
+	var ids []string
+	for si, err := range tClient.SalesInvoices.All(context.Background(), nil) {
+		require.Nil(t, err)
+		ids = append(ids, si.ID)
+	}
+
+	assert.Equal(t, []string{"invoice_1", "invoice_2"}, ids)
+}
@@ -0,0 +1,228 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Errors returned when following links.
+var (
+	ErrMissingLink   = errors.New("mollie: link not present")
+	ErrUntrustedLink = errors.New("mollie: link does not belong to the client base url")
+)
+
+// Follow retrieves the resource referenced by a link of a previously
+// fetched resource and decodes it into out.
+//
+// Only links pointing to the BaseURL of the client are followed, any other
+// href returns ErrUntrustedLink so that credentials are never sent to a
+// host taken from a response.
+func (c *Client) Follow(ctx context.Context, link *URL, out any) (res *Response, err error) {
+	if link == nil || link.Href == "" {
+		return nil, ErrMissingLink
+	}
+
+	u, err := c.trustedURL(link.Href)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := c.NewAPIRequest(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err = c.Do(req)
+	if err != nil {
+		return res, err
+	}
+
+	if out != nil {
+		if err = json.Unmarshal(res.content, out); err != nil {
+			return res, err
+		}
+	}
+
+	return res, nil
+}
+
+// trustedURL resolves href against the BaseURL of the client and returns
+// ErrUntrustedLink when it points to another scheme, host or path prefix,
+// or carries credentials.
+func (c *Client) trustedURL(href string) (*url.URL, error) {
+	u, err := c.BaseURL.Parse(href)
+	if err != nil {
+		return nil, fmt.Errorf("url_parsing_error: %w", err)
+	}
+
+	if u.User != nil ||
+		u.Scheme != c.BaseURL.Scheme ||
+		!strings.EqualFold(u.Host, c.BaseURL.Host) ||
+		!strings.HasPrefix(u.Path, c.BaseURL.Path) {
+		return nil, fmt.Errorf("%w: %s", ErrUntrustedLink, href)
+	}
+
+	return u, nil
+}
+
+// follow retrieves the resource referenced by link as a T.
+func follow[T any](ctx context.Context, c *Client, link *URL) (*Response, *T, error) {
+	v := new(T)
+
+	res, err := c.Follow(ctx, link, v)
+	if err != nil {
+		return res, nil, err
+	}
+
+	return res, v, nil
+}
+
+// FollowRefunds retrieves the refunds of the payment.
+func (l PaymentLinks) FollowRefunds(ctx context.Context, c *Client) (*Response, *RefundsList, error) {
+	return follow[RefundsList](ctx, c, l.Refunds)
+}
+
+// FollowChargebacks retrieves the chargebacks of the payment.
+func (l PaymentLinks) FollowChargebacks(ctx context.Context, c *Client) (*Response, *ChargebacksList, error) {
+	return follow[ChargebacksList](ctx, c, l.ChargeBacks)
+}
+
+// FollowCaptures retrieves the captures of the payment.
+func (l PaymentLinks) FollowCaptures(ctx context.Context, c *Client) (*Response, *CapturesList, error) {
+	return follow[CapturesList](ctx, c, l.Captures)
+}
+
+// FollowSettlement retrieves the settlement the payment is part of.
+func (l PaymentLinks) FollowSettlement(ctx context.Context, c *Client) (*Response, *Settlement, error) {
+	return follow[Settlement](ctx, c, l.Settlement)
+}
+
+// FollowMandate retrieves the mandate used to create the payment.
+func (l PaymentLinks) FollowMandate(ctx context.Context, c *Client) (*Response, *Mandate, error) {
+	return follow[Mandate](ctx, c, l.Mandate)
+}
+
+// FollowSubscription retrieves the subscription that created the payment.
+func (l PaymentLinks) FollowSubscription(ctx context.Context, c *Client) (*Response, *Subscription, error) {
+	return follow[Subscription](ctx, c, l.Subscription)
+}
+
+// FollowCustomer retrieves the customer of the payment.
+func (l PaymentLinks) FollowCustomer(ctx context.Context, c *Client) (*Response, *Customer, error) {
+	return follow[Customer](ctx, c, l.Customer)
+}
+
+// FollowOrder retrieves the order the payment was created for.
+func (l PaymentLinks) FollowOrder(ctx context.Context, c *Client) (*Response, *Order, error) {
+	return follow[Order](ctx, c, l.Order)
+}
+
+// FollowPayment retrieves the payment that was refunded.
+func (l RefundLinks) FollowPayment(ctx context.Context, c *Client) (*Response, *Payment, error) {
+	return follow[Payment](ctx, c, l.Payment)
+}
+
+// FollowSettlement retrieves the settlement the refund is part of.
+func (l RefundLinks) FollowSettlement(ctx context.Context, c *Client) (*Response, *Settlement, error) {
+	return follow[Settlement](ctx, c, l.Settlement)
+}
+
+// FollowOrder retrieves the order that was refunded.
+func (l RefundLinks) FollowOrder(ctx context.Context, c *Client) (*Response, *Order, error) {
+	return follow[Order](ctx, c, l.Order)
+}
+
+// FollowPayment retrieves the payment that was captured.
+func (l CaptureLinks) FollowPayment(ctx context.Context, c *Client) (*Response, *Payment, error) {
+	return follow[Payment](ctx, c, l.Payment)
+}
+
+// FollowShipment retrieves the shipment that triggered the capture.
+func (l CaptureLinks) FollowShipment(ctx context.Context, c *Client) (*Response, *Shipment, error) {
+	return follow[Shipment](ctx, c, l.Shipment)
+}
+
+// FollowSettlement retrieves the settlement the capture is part of.
+func (l CaptureLinks) FollowSettlement(ctx context.Context, c *Client) (*Response, *Settlement, error) {
+	return follow[Settlement](ctx, c, l.Settlement)
+}
+
+// FollowPayment retrieves the payment that was charged back.
+func (l ChargebackLinks) FollowPayment(ctx context.Context, c *Client) (*Response, *Payment, error) {
+	return follow[Payment](ctx, c, l.Payment)
+}
+
+// FollowSettlement retrieves the settlement the chargeback is part of.
+func (l ChargebackLinks) FollowSettlement(ctx context.Context, c *Client) (*Response, *Settlement, error) {
+	return follow[Settlement](ctx, c, l.Settlement)
+}
+
+// FollowPayments retrieves the payments included in the settlement.
+func (l SettlementLinks) FollowPayments(ctx context.Context, c *Client) (*Response, *PaymentList, error) {
+	return follow[PaymentList](ctx, c, l.Payments)
+}
+
+// FollowRefunds retrieves the refunds included in the settlement.
+func (l SettlementLinks) FollowRefunds(ctx context.Context, c *Client) (*Response, *RefundsList, error) {
+	return follow[RefundsList](ctx, c, l.Refunds)
+}
+
+// FollowChargebacks retrieves the chargebacks included in the settlement.
+func (l SettlementLinks) FollowChargebacks(ctx context.Context, c *Client) (*Response, *ChargebacksList, error) {
+	return follow[ChargebacksList](ctx, c, l.Chargebacks)
+}
+
+// FollowCaptures retrieves the captures included in the settlement.
+func (l SettlementLinks) FollowCaptures(ctx context.Context, c *Client) (*Response, *CapturesList, error) {
+	return follow[CapturesList](ctx, c, l.Captures)
+}
+
+// FollowInvoice retrieves the invoice of the settlement.
+func (l SettlementLinks) FollowInvoice(ctx context.Context, c *Client) (*Response, *Invoice, error) {
+	return follow[Invoice](ctx, c, l.Invoice)
+}
+
+// FollowCustomer retrieves the customer of the subscription.
+func (l SubscriptionLinks) FollowCustomer(ctx context.Context, c *Client) (*Response, *Customer, error) {
+	return follow[Customer](ctx, c, l.Customer)
+}
+
+// FollowProfile retrieves the profile the subscription belongs to.
+func (l SubscriptionLinks) FollowProfile(ctx context.Context, c *Client) (*Response, *Profile, error) {
+	return follow[Profile](ctx, c, l.Profile)
+}
+
+// FollowPayments retrieves the payments created by the subscription.
+func (l SubscriptionLinks) FollowPayments(ctx context.Context, c *Client) (*Response, *PaymentList, error) {
+	return follow[PaymentList](ctx, c, l.Payments)
+}
+
+// FollowMandates retrieves the mandates of the customer.
+func (l CustomerLinks) FollowMandates(ctx context.Context, c *Client) (*Response, *MandatesList, error) {
+	return follow[MandatesList](ctx, c, l.Mandates)
+}
+
+// FollowSubscriptions retrieves the subscriptions of the customer.
+func (l CustomerLinks) FollowSubscriptions(ctx context.Context, c *Client) (*Response, *SubscriptionsList, error) {
+	return follow[SubscriptionsList](ctx, c, l.Subscriptions)
+}
+
+// FollowPayments retrieves the payments of the customer.
+func (l CustomerLinks) FollowPayments(ctx context.Context, c *Client) (*Response, *PaymentList, error) {
+	return follow[PaymentList](ctx, c, l.Payments)
+}
+
+// FollowCustomer retrieves the customer the mandate belongs to.
+func (l MandateLinks) FollowCustomer(ctx context.Context, c *Client) (*Response, *Customer, error) {
+	return follow[Customer](ctx, c, l.Customer)
+}
+
+// FollowOrder retrieves the order of the shipment.
+func (l ShipmentLinks) FollowOrder(ctx context.Context, c *Client) (*Response, *Order, error) {
+	return follow[Order](ctx, c, l.Order)
+}
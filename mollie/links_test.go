@@ -0,0 +1,92 @@
+package mollie
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Follow(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/customers/cst_kEn1PlbGa", func(w http.ResponseWriter, r *http.Request) {
+		testHeader(t, r, AuthHeader, "Bearer token_X12b31ggg23")
+		testMethod(t, r, "GET")
+		_, _ = w.Write([]byte(testdata.GetCustomerResponse))
+	})
+
+	cases := []struct {
+		name string
+		link *URL
+		err  error
+	}{
+		{"absolute links to the base url are followed", &URL{Href: tServer.URL + "/v2/customers/cst_kEn1PlbGa"}, nil},
+		{"relative links are resolved against the base url", &URL{Href: "v2/customers/cst_kEn1PlbGa"}, nil},
+		{"nil links are missing", nil, ErrMissingLink},
+		{"empty links are missing", &URL{}, ErrMissingLink},
+		{"links to other hosts are rejected", &URL{Href: "https://example.org/v2/customers/cst_kEn1PlbGa"}, ErrUntrustedLink},
+		{"links with another scheme are rejected", &URL{Href: "ftp" + tServer.URL[4:] + "/v2/customers"}, ErrUntrustedLink},
+		{"links with user info are rejected", &URL{Href: "http://mollie@" + tServer.URL[7:] + "/v2/customers"}, ErrUntrustedLink},
+		{"protocol relative links to other hosts are rejected", &URL{Href: "//example.org/v2/customers"}, ErrUntrustedLink},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			var cst Customer
+
+			_, err := tClient.Follow(context.Background(), c.link, &cst)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, "cst_kEn1PlbGa", cst.ID)
+		})
+	}
+}
+
+func TestLinks_FollowTyped(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/customers/cst_kEn1PlbGa", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.GetCustomerResponse))
+	})
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN/payments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+	})
+
+	ctx := context.Background()
+	href := func(path string) *URL { return &URL{Href: tServer.URL + path} }
+
+	_, cst, err := PaymentLinks{Customer: href("/v2/customers/cst_kEn1PlbGa")}.FollowCustomer(ctx, tClient)
+	require.Nil(t, err)
+	assert.Equal(t, "cst_kEn1PlbGa", cst.ID)
+
+	_, cst, err = SubscriptionLinks{Customer: href("/v2/customers/cst_kEn1PlbGa")}.FollowCustomer(ctx, tClient)
+	require.Nil(t, err)
+	assert.Equal(t, "cst_kEn1PlbGa", cst.ID)
+
+	_, payments, err := SettlementLinks{Payments: href("/v2/settlements/stl_jDk30akdN/payments")}.FollowPayments(ctx, tClient)
+	require.Nil(t, err)
+	assert.NotEmpty(t, payments.Embedded.Payments)
+
+	_, mdt, err := PaymentLinks{}.FollowMandate(ctx, tClient)
+	assert.ErrorIs(t, err, ErrMissingLink)
+	assert.Nil(t, mdt)
+}
@@ -0,0 +1,154 @@
+package mollie
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+// sensitiveFields contains the names of the payload fields holding
+// credentials or personal data.
+var sensitiveFields = []string{
+	"access_token",
+	"applePayPaymentToken",
+	"bankAccount",
+	"billingEmail",
+	"cardFingerprint",
+	"cardHolder",
+	"cardNumber",
+	"cardToken",
+	"client_secret",
+	"consumerAccount",
+	"consumerBic",
+	"consumerEmail",
+	"consumerName",
+	"email",
+	"familyName",
+	"givenName",
+	"iban",
+	"ipAddress",
+	"name",
+	"phone",
+	"postalCode",
+	"refresh_token",
+	"secret",
+	"streetAdditional",
+	"streetAndNumber",
+	"voucherNumber",
+	"voucherPin",
+	"webhookSecret",
+}
+
+// SensitiveFields returns the names of the payload fields holding
+// credentials or personal data, which are never written to the logs.
+// Field names are matched case insensitively.
+func SensitiveFields() []string {
+	return slices.Clone(sensitiveFields)
+}
+
+func isSensitive(field string) bool {
+	return slices.ContainsFunc(sensitiveFields, func(f string) bool {
+		return strings.EqualFold(f, field)
+	})
+}
+
+// logAttempt writes a debug record describing a single attempt to send req.
+//
+// The Authorization header is never logged, bodies are only logged when
+// enabled in the Config and after redacting their sensitive fields.
+func (c *Client) logAttempt(req *http.Request, res *Response, err error, attempt int, elapsed time.Duration) {
+	if c.config == nil || c.config.logger == nil {
+		return
+	}
+
+	ctx := req.Context()
+	logger := c.config.logger
+
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", req.Method),
+		slog.String("path", req.URL.Path),
+		slog.Int("attempt", attempt),
+		slog.Duration("duration", elapsed),
+	}
+
+	if key := req.Header.Get(IdempotencyKeyHeader); key != "" {
+		attrs = append(attrs, slog.String("idempotency_key", key))
+	}
+
+	if res != nil && res.Response != nil {
+		attrs = append(attrs,
+			slog.Int("status", res.StatusCode),
+			slog.String("request_id", res.Header.Get(RequestIDHeader)),
+		)
+	}
+
+	if c.config.logBodies {
+		if req.GetBody != nil {
+			if body, berr := req.GetBody(); berr == nil {
+				content, _ := io.ReadAll(body)
+				attrs = append(attrs, slog.String("request_body", redactJSON(content)))
+			}
+		}
+
+		if res != nil && len(res.content) > 0 {
+			attrs = append(attrs, slog.String("response_body", redactJSON(res.content)))
+		}
+	}
+
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+
+	logger.LogAttrs(ctx, slog.LevelDebug, "mollie api request", attrs...)
+}
+
+// redactJSON replaces the values of the sensitive fields in a JSON payload,
+// payloads that are not valid JSON are omitted.
+func redactJSON(content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	var payload any
+	if err := json.Unmarshal(content, &payload); err != nil {
+		return "[OMITTED]"
+	}
+
+	out, err := json.Marshal(redact(payload))
+	if err != nil {
+		return "[OMITTED]"
+	}
+
+	return string(out)
+}
+
+func redact(v any) any {
+	switch val := v.(type) {
+	case map[string]any:
+		for k, field := range val {
+			if isSensitive(k) {
+				val[k] = redacted
+
+				continue
+			}
+
+			val[k] = redact(field)
+		}
+	case []any:
+		for i, item := range val {
+			val[i] = redact(item)
+		}
+	}
+
+	return v
+}
@@ -0,0 +1,83 @@
+package mollie
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactJSON(t *testing.T) {
+	in := `{"amount":{"value":"10.00"},"cardToken":"tkn_123","lines":[{"voucherPin":"1234","sku":"A"}],` +
+		`"ConsumerAccount":"NL55INGB0000000000","billingAddress":{"givenName":"Jane","familyName":"Doe",` +
+		`"streetAndNumber":"Keizersgracht 126","postalCode":"1015 CW","city":"Amsterdam"},"iban":"NL55INGB0000000000"}`
+
+	got := redactJSON([]byte(in))
+
+	assert.JSONEq(t, `{"amount":{"value":"10.00"},"cardToken":"[REDACTED]",`+
+		`"lines":[{"voucherPin":"[REDACTED]","sku":"A"}],"ConsumerAccount":"[REDACTED]",`+
+		`"billingAddress":{"givenName":"[REDACTED]","familyName":"[REDACTED]",`+
+		`"streetAndNumber":"[REDACTED]","postalCode":"[REDACTED]","city":"Amsterdam"},"iban":"[REDACTED]"}`, got)
+	assert.Equal(t, "[OMITTED]", redactJSON([]byte("cardToken=tkn_123")))
+	assert.Empty(t, redactJSON(nil))
+}
+
+func TestClient_Do_Logging(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	var buf bytes.Buffer
+
+	tConf.SetLogger(slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	tConf.ToggleBodyLogging()
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, "req_123")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	})
+
+	_, _, err := tClient.Payments.Create(context.Background(), CreatePayment{CardToken: "tkn_secret"}, nil)
+	require.Nil(t, err)
+
+	var record map[string]any
+	require.Nil(t, json.Unmarshal(buf.Bytes(), &record))
+
+	assert.Equal(t, "mollie api request", record["msg"])
+	assert.Equal(t, http.MethodPost, record["method"])
+	assert.Equal(t, "/v2/payments", record["path"])
+	assert.Equal(t, float64(http.StatusCreated), record["status"])
+	assert.Equal(t, "req_123", record["request_id"])
+	assert.NotEmpty(t, record["idempotency_key"])
+	assert.Contains(t, record["request_body"], `"cardToken":"[REDACTED]"`)
+	assert.Contains(t, record["response_body"], "tr_WDqYK6vllg")
+	assert.NotContains(t, buf.String(), "tkn_secret")
+	assert.NotContains(t, buf.String(), "token_X12b31ggg23")
+}
+
+func TestClient_Do_LoggingDisabledAboveDebug(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	var buf bytes.Buffer
+
+	tConf.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testdata.ListPaymentsResponse))
+	})
+
+	_, _, err := tClient.Payments.List(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Empty(t, buf.String())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -125,10 +126,6 @@ func (ms *MandatesService) Create(ctx context.Context, customer string, mandate
 ) {
 	u := fmt.Sprintf("v2/customers/%s/mandates", customer)
 
-	if ms.client.HasAccessToken() && ms.client.config.testing {
-		mandate.Testmode = true
-	}
-
 	res, err = ms.client.post(ctx, u, mandate, nil)
 	if err != nil {
 		return
@@ -200,3 +197,16 @@ func (ms *MandatesService) List(ctx context.Context, customer string, options *L
 
 	return
 }
+
+// All returns an iterator over all mandates for the given customer.
+func (ms *MandatesService) All(
+	ctx context.Context,
+	customer string,
+	options *ListMandatesOptions,
+) iter.Seq2[*Mandate, error] {
+	u := fmt.Sprintf("v2/customers/%s/mandates", customer)
+
+	return paginate(ctx, ms.client, u, options, func(l *MandatesList) ([]*Mandate, *URL) {
+		return l.Embedded.Mandates, l.Links.Next
+	})
+}
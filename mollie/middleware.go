@@ -0,0 +1,26 @@
+package mollie
+
+import "net/http"
+
+// Doer sends an API request and returns the API response.
+type Doer func(req *http.Request) (*Response, error)
+
+// Middleware wraps the Doer used by the client to send requests.
+//
+// A middleware receives the fully built request, including the authentication
+// and idempotency headers, and the resulting response and error. It can
+// modify the request, inspect the response or short-circuit the call by
+// returning an error without invoking next.
+//
+// Middleware runs once per call to Client.Do, the retries performed by the
+// RetryPolicy happen inside the chain.
+type Middleware func(next Doer) Doer
+
+// Use appends middleware to the chain executed by Client.Do, the first
+// middleware registered is the outermost one.
+//
+// Use is not safe to call concurrently with requests, register the
+// middleware while setting up the client.
+func (c *Client) Use(mw ...Middleware) {
+	c.middleware = append(c.middleware, mw...)
+}
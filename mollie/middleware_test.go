@@ -0,0 +1,80 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_Use(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	var order []string
+
+	trace := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return func(req *http.Request) (*Response, error) {
+				order = append(order, name+":before")
+				res, err := next(req)
+				order = append(order, name+":after")
+
+				return res, err
+			}
+		}
+	}
+
+	tClient.Use(trace("outer"), trace("inner"))
+	tClient.Use(func(next Doer) Doer {
+		return func(req *http.Request) (*Response, error) {
+			assert.NotEmpty(t, req.Header.Get(AuthHeader))
+			req.Header.Set("X-Signature", "signed")
+
+			res, err := next(req)
+			assert.Equal(t, http.StatusTeapot, res.StatusCode)
+			assert.NotNil(t, err)
+
+			return res, nil
+		}
+	})
+
+	tMux.HandleFunc("/v2/middleware", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "signed", r.Header.Get("X-Signature"))
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	req, err := tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/middleware", nil)
+	require.Nil(t, err)
+
+	_, err = tClient.Do(req)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"outer:before", "inner:before", "inner:after", "outer:after"}, order)
+}
+
+func TestClient_UseShortCircuit(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	blocked := errors.New("blocked by middleware")
+
+	tClient.Use(func(next Doer) Doer {
+		return func(req *http.Request) (*Response, error) {
+			return nil, blocked
+		}
+	})
+
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request must not be sent")
+	})
+
+	_, _, err := tClient.Payments.List(context.Background(), nil)
+	assert.ErrorIs(t, err, blocked)
+}
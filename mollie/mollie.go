@@ -13,6 +13,7 @@ import (
 	"regexp"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/VictorAvelar/mollie-api-go/v4/pkg/idempotency"
 	"github.com/google/go-querystring/query"
@@ -43,12 +44,15 @@ var (
 type Client struct {
 	BaseURL        *url.URL
 	authentication string
+	authProvider   AuthProvider
+	defaults       requestDefaults
 	userAgent      string
 	client         *http.Client
 	common         service // Reuse a single struct instead of allocating one for each service on the heap.
 	config         *Config
 	// Tools
 	idempotencyKeyProvider idempotency.KeyGenerator
+	middleware             []Middleware
 	// Services
 	Payments       *PaymentsService
 	Chargebacks    *ChargebacksService
@@ -140,12 +144,15 @@ func (c *Client) delete(ctx context.Context, uri string, body interface{}) (res
 // Ideally your API key will be provided from and environment variable or
 // a secret management engine.
 // This should only be used when environment variables are "impossible" to be used.
+//
+// Setting a value removes the AuthProvider set using SetAuthProvider.
 func (c *Client) WithAuthenticationValue(k string) error {
 	if k == "" {
 		return errEmptyAuthKey
 	}
 
 	c.authentication = strings.TrimSpace(k)
+	c.authProvider = nil
 
 	return nil
 }
@@ -154,8 +161,14 @@ func (c *Client) WithAuthenticationValue(k string) error {
 // complies with the access token REGEXP match check.
 // This will enable TestMode inside the request body.
 //
+// When an AuthProvider is set, it decides whether its tokens are access tokens.
+//
 // See: https://github.com/VictorAvelar/mollie-api-go/issues/123
 func (c *Client) HasAccessToken() bool {
+	if c.authProvider != nil {
+		return c.authProvider.IsAccessToken()
+	}
+
 	return accessTokenExpr.Match([]byte(c.authentication))
 }
 
@@ -165,9 +178,30 @@ func (c *Client) SetIdempotencyKeyGenerator(kg idempotency.KeyGenerator) {
 	c.idempotencyKeyProvider = kg
 }
 
+type idempotencyKeyCtx struct{}
+
+// WithIdempotencyKey returns a copy of ctx carrying the given idempotency key.
+//
+// POST, PATCH and DELETE requests built with the returned context send
+// this key instead of one created by the idempotency key generator, this
+// allows the same logical operation to be repeated safely, e.g. after
+// a crash of the calling process.
+func WithIdempotencyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, idempotencyKeyCtx{}, key)
+}
+
+func idempotencyKeyFromContext(ctx context.Context) (string, bool) {
+	key, ok := ctx.Value(idempotencyKeyCtx{}).(string)
+
+	return key, ok && key != ""
+}
+
 // NewAPIRequest is a wrapper around the http.NewRequest function.
 //
 // It will setup the authentication headers/parameters according to the client config.
+//
+// When validation is enabled in the config, bodies implementing Validator
+// are validated before building the request.
 func (c *Client) NewAPIRequest(ctx context.Context, method string, uri string, body interface{}) (
 	req *http.Request,
 	err error,
@@ -182,10 +216,24 @@ func (c *Client) NewAPIRequest(ctx context.Context, method string, uri string, b
 		return nil, fmt.Errorf("url_parsing_error: %w", err)
 	}
 
-	if c.config.testing && c.HasAccessToken() {
-		qp := url.Query()
-		qp.Add("testmode", "true")
-		url.RawQuery = qp.Encode()
+	if v, ok := body.(Validator); ok && c.config.validation {
+		if err := v.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	defaults := c.defaults
+	if c.HasAccessToken() {
+		defaults.testmode = defaults.testmode || c.config.testing
+
+		if defaults.profileID == "" {
+			defaults.profileID = c.config.profileID
+		}
+	}
+
+	body, err = defaults.apply(method, url, body)
+	if err != nil {
+		return nil, err
 	}
 
 	var buf io.ReadWriter
@@ -209,27 +257,146 @@ func (c *Client) NewAPIRequest(ctx context.Context, method string, uri string, b
 		return nil, fmt.Errorf("new_request: %w", err)
 	}
 
+	if err := c.authorize(req); err != nil {
+		return nil, fmt.Errorf("authentication_error: %w", err)
+	}
+
 	c.addRequestHeaders(req)
 
 	return req, nil
 }
 
 func (c *Client) addRequestHeaders(req *http.Request) {
-	req.Header.Add(AuthHeader, strings.Join([]string{TokenType, c.authentication}, " "))
 	req.Header.Set("Content-Type", RequestContentType)
 	req.Header.Set("Accept", RequestContentType)
 	req.Header.Set("User-Agent", c.userAgent)
 
+	if key, ok := idempotencyKeyFromContext(req.Context()); ok && req.Method != http.MethodGet {
+		req.Header.Set(IdempotencyKeyHeader, key)
+
+		return
+	}
+
 	if c.config.reqIdempotency &&
 		c.idempotencyKeyProvider != nil &&
-		req.Method == http.MethodPost {
+		acceptsIdempotencyKey(req.Method) {
 		req.Header.Set(IdempotencyKeyHeader, c.idempotencyKeyProvider.Generate())
 	}
 }
 
+// acceptsIdempotencyKey reports if Mollie honours the Idempotency-Key
+// header for requests using method.
+func acceptsIdempotencyKey(method string) bool {
+	switch method {
+	case http.MethodPost, http.MethodPatch, http.MethodDelete:
+		return true
+	default:
+		return false
+	}
+}
+
 // Do sends an API request and returns the API response or returned as an
 // error if an API error has occurred.
+//
+// When the Config contains a RetryPolicy, failed requests that are safe to
+// repeat are retried following the policy, reusing the same request and
+// therefore the same Idempotency-Key header.
+//
+// When the Config contains a CircuitBreaker, attempts to an endpoint group
+// whose circuit is open fail immediately with ErrCircuitOpen, and when it
+// contains a RateLimiter, the other attempts wait for their budget before
+// being sent.
+//
+// When the Config contains a ResponseCache, the GET requests of the cached
+// endpoint groups are served from it.
+//
+// The request goes through the middleware registered with Use before
+// being sent.
 func (c *Client) Do(req *http.Request) (*Response, error) {
+	next := c.send
+	if c.config != nil && c.config.cache != nil {
+		next = c.config.cache.wrap(next)
+	}
+
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		next = c.middleware[i](next)
+	}
+
+	return next(req)
+}
+
+func (c *Client) send(req *http.Request) (*Response, error) {
+	var policy RetryPolicy
+	if c.config != nil && c.config.retryPolicy != nil && canRetry(req) {
+		policy = c.config.retryPolicy
+	}
+
+	var (
+		limiter *RateLimiter
+		breaker *CircuitBreaker
+	)
+
+	if c.config != nil {
+		limiter = c.config.rateLimiter
+		breaker = c.config.circuitBreaker
+	}
+
+	for attempt := 1; ; attempt++ {
+		// an open circuit fails fast, without consuming rate limit budget.
+		if breaker != nil {
+			if err := breaker.Allow(req); err != nil {
+				return nil, err
+			}
+		}
+
+		if limiter != nil {
+			if err := limiter.Wait(req); err != nil {
+				if breaker != nil {
+					breaker.release(req)
+				}
+
+				return nil, fmt.Errorf("rate_limit: %w", err)
+			}
+		}
+
+		start := time.Now()
+		response, err := c.do(req)
+		c.logAttempt(req, response, err, attempt, time.Since(start))
+
+		if limiter != nil {
+			limiter.Observe(req, response)
+		}
+
+		if breaker != nil {
+			breaker.Observe(req, response, err)
+		}
+
+		if err == nil || policy == nil {
+			return response, err
+		}
+
+		delay, retry := policy.Retry(attempt, response, err)
+		if !retry {
+			return response, err
+		}
+
+		if werr := wait(req.Context(), delay); werr != nil {
+			return response, err
+		}
+
+		if rerr := rewind(req); rerr != nil {
+			return response, err
+		}
+
+		if c.authProvider != nil {
+			if aerr := c.authorize(req); aerr != nil {
+				return response, err
+			}
+		}
+	}
+}
+
+func (c *Client) do(req *http.Request) (*Response, error) {
 	resp, err := c.client.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("http_error: %w", err)
@@ -279,33 +446,7 @@ func NewClient(baseClient *http.Client, conf *Config) (mollie *Client, err error
 		mollie.common.client.idempotencyKeyProvider = idempotency.NewStdGenerator()
 	}
 
-	// services for resources
-	mollie.Payments = (*PaymentsService)(&mollie.common)
-	mollie.Chargebacks = (*ChargebacksService)(&mollie.common)
-	mollie.PaymentMethods = (*PaymentMethodsService)(&mollie.common)
-	mollie.Invoices = (*InvoicesService)(&mollie.common)
-	mollie.Organizations = (*OrganizationsService)(&mollie.common)
-	mollie.Profiles = (*ProfilesService)(&mollie.common)
-	mollie.Refunds = (*RefundsService)(&mollie.common)
-	mollie.Shipments = (*ShipmentsService)(&mollie.common)
-	mollie.Orders = (*OrdersService)(&mollie.common)
-	mollie.Captures = (*CapturesService)(&mollie.common)
-	mollie.Settlements = (*SettlementsService)(&mollie.common)
-	mollie.Subscriptions = (*SubscriptionsService)(&mollie.common)
-	mollie.Customers = (*CustomersService)(&mollie.common)
-	mollie.Wallets = (*WalletsService)(&mollie.common)
-	mollie.Mandates = (*MandatesService)(&mollie.common)
-	mollie.Permissions = (*PermissionsService)(&mollie.common)
-	mollie.Onboarding = (*OnboardingService)(&mollie.common)
-	mollie.PaymentLinks = (*PaymentLinksService)(&mollie.common)
-	mollie.Clients = (*ClientsService)(&mollie.common)
-	mollie.Balances = (*BalancesService)(&mollie.common)
-	mollie.ClientLinks = (*ClientLinksService)(&mollie.common)
-	mollie.Terminals = (*TerminalsService)(&mollie.common)
-	mollie.SalesInvoices = (*SalesInvoicesService)(&mollie.common)
-	mollie.DelayedRouting = (*DelayedRoutingService)(&mollie.common)
-	mollie.Webhooks = (*WebhookService)(&mollie.common)
-	mollie.WebhookEvents = (*WebhookEventService)(&mollie.common)
+	mollie.initServices()
 
 	mollie.userAgent = strings.Join([]string{
 		ClientName,
@@ -322,21 +463,64 @@ func NewClient(baseClient *http.Client, conf *Config) (mollie *Client, err error
 	return mollie, nil
 }
 
+// initServices points every service of the client to its common service.
+func (c *Client) initServices() {
+	c.common.client = c
+
+	c.Payments = (*PaymentsService)(&c.common)
+	c.Chargebacks = (*ChargebacksService)(&c.common)
+	c.PaymentMethods = (*PaymentMethodsService)(&c.common)
+	c.Invoices = (*InvoicesService)(&c.common)
+	c.Organizations = (*OrganizationsService)(&c.common)
+	c.Profiles = (*ProfilesService)(&c.common)
+	c.Refunds = (*RefundsService)(&c.common)
+	c.Shipments = (*ShipmentsService)(&c.common)
+	c.Orders = (*OrdersService)(&c.common)
+	c.Captures = (*CapturesService)(&c.common)
+	c.Settlements = (*SettlementsService)(&c.common)
+	c.Subscriptions = (*SubscriptionsService)(&c.common)
+	c.Customers = (*CustomersService)(&c.common)
+	c.Wallets = (*WalletsService)(&c.common)
+	c.Mandates = (*MandatesService)(&c.common)
+	c.Permissions = (*PermissionsService)(&c.common)
+	c.Onboarding = (*OnboardingService)(&c.common)
+	c.PaymentLinks = (*PaymentLinksService)(&c.common)
+	c.Clients = (*ClientsService)(&c.common)
+	c.Balances = (*BalancesService)(&c.common)
+	c.ClientLinks = (*ClientLinksService)(&c.common)
+	c.Terminals = (*TerminalsService)(&c.common)
+	c.SalesInvoices = (*SalesInvoicesService)(&c.common)
+	c.DelayedRouting = (*DelayedRoutingService)(&c.common)
+	c.Webhooks = (*WebhookService)(&c.common)
+	c.WebhookEvents = (*WebhookEventService)(&c.common)
+}
+
 /*
 Constructor for Error.
+
+A response body that can not be decoded as a Mollie error still
+yields a BaseError, containing the raw body as detail.
 */
 func newError(rsp *Response) error {
 	baseErr := &BaseError{}
 
-	if rsp.ContentLength > 0 {
-		err := json.Unmarshal(rsp.content, baseErr)
-		if err != nil {
-			return err
+	if len(rsp.content) == 0 || json.Unmarshal(rsp.content, baseErr) != nil {
+		baseErr = &BaseError{
+			Title:  rsp.Status,
+			Detail: string(rsp.content),
 		}
-	} else {
+	}
+
+	if baseErr.Status == 0 {
 		baseErr.Status = rsp.StatusCode
-		baseErr.Title = rsp.Status
-		baseErr.Detail = string(rsp.content)
+	}
+
+	if rsp.Header != nil {
+		baseErr.RequestID = rsp.Header.Get(RequestIDHeader)
+	}
+
+	if d, ok := retryAfter(rsp); ok {
+		baseErr.RetryAfter = d
 	}
 
 	return baseErr
@@ -2,6 +2,7 @@ package mollie
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -189,7 +190,13 @@ func TestClient_NewApiRequest_IdempotencyKeys(t *testing.T) {
 			false,
 		},
 		{
-			"using the std key generator with a non supported method (delete)",
+			"using the std key generator with patch",
+			args{http.MethodPatch},
+			"",
+			false,
+		},
+		{
+			"using the std key generator with delete",
 			args{http.MethodDelete},
 			"",
 			false,
@@ -215,7 +222,7 @@ func TestClient_NewApiRequest_IdempotencyKeys(t *testing.T) {
 
 			assert.Nil(t, err)
 
-			if tt.args.method != http.MethodPost {
+			if !acceptsIdempotencyKey(tt.args.method) {
 				assert.Empty(t, req.Header.Get(IdempotencyKeyHeader))
 			} else {
 				assert.NotEmpty(t, req.Header.Get(IdempotencyKeyHeader))
@@ -596,13 +603,13 @@ func TestClient_newError(t *testing.T) {
 		err     error
 	}{
 		{
-			"new error fails on read operation",
+			"new error with an empty body is constructed based on response",
 			args{
 				&Response{Response: &http.Response{Body: closedReader(), ContentLength: 1}},
 			},
 			&BaseError{},
-			true,
-			fmt.Errorf("unexpected end of JSON input"),
+			false,
+			nil,
 		},
 		{
 			"new error is constructed based on response",
@@ -613,6 +620,48 @@ func TestClient_newError(t *testing.T) {
 			false,
 			nil,
 		},
+		{
+			"new error with a non json body is still a base error",
+			args{
+				&Response{
+					Response: &http.Response{
+						StatusCode: http.StatusBadGateway,
+						Status:     "502 Bad Gateway",
+						Header:     http.Header{RequestIDHeader: {"req_123"}},
+					},
+					content: []byte("<html>Bad Gateway</html>"),
+				},
+			},
+			&BaseError{
+				Status:    http.StatusBadGateway,
+				Title:     "502 Bad Gateway",
+				Detail:    "<html>Bad Gateway</html>",
+				RequestID: "req_123",
+			},
+			false,
+			nil,
+		},
+		{
+			"new error with a json body contains the retry information",
+			args{
+				&Response{
+					Response: &http.Response{
+						StatusCode: http.StatusTooManyRequests,
+						Status:     "429 Too Many Requests",
+						Header:     http.Header{"Retry-After": {"30"}},
+					},
+					content: []byte(`{"title": "Too Many Requests", "detail": "slow down"}`),
+				},
+			},
+			&BaseError{
+				Status:     http.StatusTooManyRequests,
+				Title:      "Too Many Requests",
+				Detail:     "slow down",
+				RetryAfter: 30 * time.Second,
+			},
+			false,
+			nil,
+		},
 	}
 
 	for _, c := range cases {
@@ -691,6 +740,17 @@ func testQuery(t *testing.T, r *http.Request, want string) {
 	}
 }
 
+func testTestmodeBody(t *testing.T, r *http.Request) {
+	var body map[string]any
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil || body["testmode"] != true {
+		t.Errorf("request body does not enable testmode, got %v", body)
+	}
+
+	if r.URL.Query().Has("testmode") {
+		t.Errorf("testmode must not be sent in the query of %s requests", r.Method)
+	}
+}
+
 func errorHandler(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusInternalServerError)
 	w.Write([]byte(testdata.InternalServerErrorResponse))
@@ -0,0 +1,25 @@
+package mollietest
+
+import (
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// cents parses the value of a non negative amount into minor units.
+func cents(a *mollie.Amount) (int64, bool) {
+	if a == nil {
+		return 0, false
+	}
+
+	m, err := a.Money()
+	if err != nil || m.IsNegative() {
+		return 0, false
+	}
+
+	return m.Minor(), true
+}
+
+func amount(currency string, minor int64) *mollie.Amount {
+	m, _ := mollie.NewMoney(currency, minor)
+
+	return m.Amount()
+}
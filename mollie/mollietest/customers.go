@@ -0,0 +1,550 @@
+package mollietest
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+func (s *Server) routeCustomers() {
+	s.mux.HandleFunc("POST /v2/customers", s.createCustomer)
+	s.mux.HandleFunc("GET /v2/customers", s.listCustomers)
+	s.mux.HandleFunc("GET /v2/customers/{id}", s.getCustomer)
+	s.mux.HandleFunc("PATCH /v2/customers/{id}", s.updateCustomer)
+	s.mux.HandleFunc("DELETE /v2/customers/{id}", s.deleteCustomer)
+	s.mux.HandleFunc("POST /v2/customers/{id}/payments", s.createCustomerPayment)
+	s.mux.HandleFunc("GET /v2/customers/{id}/payments", s.listCustomerPayments)
+
+	s.mux.HandleFunc("POST /v2/customers/{id}/mandates", s.createMandate)
+	s.mux.HandleFunc("GET /v2/customers/{id}/mandates", s.listMandates)
+	s.mux.HandleFunc("GET /v2/customers/{id}/mandates/{mandate}", s.getMandate)
+	s.mux.HandleFunc("DELETE /v2/customers/{id}/mandates/{mandate}", s.revokeMandate)
+
+	s.mux.HandleFunc("GET /v2/subscriptions", s.listAllSubscriptions)
+	s.mux.HandleFunc("POST /v2/customers/{id}/subscriptions", s.createSubscription)
+	s.mux.HandleFunc("GET /v2/customers/{id}/subscriptions", s.listSubscriptions)
+	s.mux.HandleFunc("GET /v2/customers/{id}/subscriptions/{sub}", s.getSubscription)
+	s.mux.HandleFunc("PATCH /v2/customers/{id}/subscriptions/{sub}", s.updateSubscription)
+	s.mux.HandleFunc("DELETE /v2/customers/{id}/subscriptions/{sub}", s.cancelSubscription)
+	s.mux.HandleFunc("GET /v2/customers/{id}/subscriptions/{sub}/payments", s.listSubscriptionPayments)
+}
+
+func (s *Server) customer(w http.ResponseWriter, r *http.Request) (*mollie.Customer, bool) {
+	c, ok := s.customers.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "customer", r.PathValue("id"))
+	}
+
+	return c, ok
+}
+
+func (s *Server) createCustomer(w http.ResponseWriter, r *http.Request) {
+	var cc mollie.CreateCustomer
+	if !decode(w, r, &cc) {
+		return
+	}
+
+	id := s.newID("cst")
+	c := &mollie.Customer{
+		Resource:  "customer",
+		ID:        id,
+		Mode:      mollie.TestMode,
+		Name:      cc.Name,
+		Email:     cc.Email,
+		Locale:    cc.Locale,
+		Metadata:  cc.Metadata,
+		CreatedAt: s.timestamp(),
+		Links: mollie.CustomerLinks{
+			Self:          s.link("/v2/customers/%s", id),
+			Mandates:      s.link("/v2/customers/%s/mandates", id),
+			Subscriptions: s.link("/v2/customers/%s/subscriptions", id),
+			Payments:      s.link("/v2/customers/%s/payments", id),
+		},
+	}
+
+	s.customers.add(id, c)
+	writeJSON(w, http.StatusCreated, c)
+}
+
+func (s *Server) listCustomers(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.customers.list(nil)
+	writeList(w, r, s, "customers", ids, items)
+}
+
+func (s *Server) getCustomer(w http.ResponseWriter, r *http.Request) {
+	if c, ok := s.customer(w, r); ok {
+		writeJSON(w, http.StatusOK, c)
+	}
+}
+
+func (s *Server) updateCustomer(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	var uc mollie.UpdateCustomer
+	if !decode(w, r, &uc) {
+		return
+	}
+
+	if uc.Name != "" {
+		c.Name = uc.Name
+	}
+
+	if uc.Email != "" {
+		c.Email = uc.Email
+	}
+
+	if uc.Locale != "" {
+		c.Locale = uc.Locale
+	}
+
+	if uc.Metadata != nil {
+		c.Metadata = uc.Metadata
+	}
+
+	writeJSON(w, http.StatusOK, c)
+}
+
+// deleteCustomer removes the customer along with its mandates and
+// subscriptions, which are canceled by Mollie.
+func (s *Server) deleteCustomer(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	for id, owner := range s.owners {
+		if owner != c.ID {
+			continue
+		}
+
+		s.mandates.remove(id)
+		s.subscriptions.remove(id)
+		delete(s.owners, id)
+	}
+
+	s.customers.remove(c.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createCustomerPayment(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	var cp mollie.CreatePayment
+	if !decode(w, r, &cp) {
+		return
+	}
+
+	cp.CustomerID = c.ID
+
+	if p, ok := s.newPayment(w, &cp); ok {
+		writeJSON(w, http.StatusCreated, p)
+	}
+}
+
+func (s *Server) listCustomerPayments(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	ids, items := s.payments.list(func(p *mollie.Payment) bool { return p.CustomerID == c.ID })
+	writeList(w, r, s, "payments", ids, items)
+}
+
+// validMandate returns the given mandate of the customer, or any of its
+// valid mandates when mandateID is empty, if it is valid.
+func (s *Server) validMandate(customerID, mandateID string) *mollie.Mandate {
+	_, mandates := s.mandates.list(func(m *mollie.Mandate) bool {
+		return s.owners[m.ID] == customerID &&
+			m.Status == mollie.ValidMandate &&
+			(mandateID == "" || m.ID == mandateID)
+	})
+
+	if len(mandates) == 0 {
+		return nil
+	}
+
+	return mandates[0]
+}
+
+func (s *Server) createMandate(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	var cm mollie.CreateMandate
+	if !decode(w, r, &cm) {
+		return
+	}
+
+	if cm.Method != mollie.DirectDebit && cm.Method != mollie.PayPal {
+		writeError(w, http.StatusUnprocessableEntity, "The payment method is invalid", "method")
+
+		return
+	}
+
+	if cm.ConsumerName == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The consumer name is invalid", "consumerName")
+
+		return
+	}
+
+	if cm.Method == mollie.DirectDebit && cm.ConsumerAccount == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The consumer account is invalid", "consumerAccount")
+
+		return
+	}
+
+	id := s.newID("mdt")
+	m := &mollie.Mandate{
+		Resource:         "mandate",
+		ID:               id,
+		Mode:             mollie.TestMode,
+		Status:           mollie.ValidMandate,
+		Method:           cm.Method,
+		MandateReference: cm.MandateReference,
+		SignatureDate:    cm.SignatureDate,
+		ConsumerName:     cm.ConsumerName,
+		ConsumerAccount:  cm.ConsumerAccount,
+		ConsumerBic:      cm.ConsumerBIC,
+		Details: mollie.MandateDetails{
+			ConsumerName:    cm.ConsumerName,
+			ConsumerAccount: cm.ConsumerAccount,
+			ConsumerBic:     cm.ConsumerBIC,
+		},
+		CreatedAt: s.timestamp(),
+		Links: mollie.MandateLinks{
+			Self:     s.link("/v2/customers/%s/mandates/%s", c.ID, id),
+			Customer: s.link("/v2/customers/%s", c.ID),
+		},
+	}
+
+	s.mandates.add(id, m)
+	s.owners[id] = c.ID
+	writeJSON(w, http.StatusCreated, m)
+}
+
+func (s *Server) listMandates(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	ids, items := s.mandates.list(func(m *mollie.Mandate) bool { return s.owners[m.ID] == c.ID })
+	writeList(w, r, s, "mandates", ids, items)
+}
+
+func (s *Server) mandate(w http.ResponseWriter, r *http.Request) (*mollie.Mandate, bool) {
+	m, ok := s.mandates.get(r.PathValue("mandate"))
+	if !ok || s.owners[m.ID] != r.PathValue("id") {
+		writeNotFound(w, "mandate", r.PathValue("mandate"))
+
+		return nil, false
+	}
+
+	return m, true
+}
+
+func (s *Server) getMandate(w http.ResponseWriter, r *http.Request) {
+	if m, ok := s.mandate(w, r); ok {
+		writeJSON(w, http.StatusOK, m)
+	}
+}
+
+// revokeMandate invalidates the mandate, subscriptions relying on it
+// can not be charged anymore.
+func (s *Server) revokeMandate(w http.ResponseWriter, r *http.Request) {
+	m, ok := s.mandate(w, r)
+	if !ok {
+		return
+	}
+
+	m.Status = mollie.InvalidMandate
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) createSubscription(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	var cs mollie.CreateSubscription
+	if !decode(w, r, &cs) {
+		return
+	}
+
+	if _, valid := cents(cs.Amount); !valid {
+		writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+		return
+	}
+
+	if _, err := parseInterval(cs.Interval); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "The interval is invalid", "interval")
+
+		return
+	}
+
+	if cs.Description == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The description is invalid", "description")
+
+		return
+	}
+
+	m := s.validMandate(c.ID, cs.MandateID)
+	if m == nil {
+		writeError(w, http.StatusUnprocessableEntity, "The customer does not have a valid mandate", "mandateId")
+
+		return
+	}
+
+	start := cs.StartDate
+	if start == nil {
+		start = &mollie.ShortDate{Time: s.now().UTC().Truncate(24 * time.Hour)}
+	}
+
+	id := s.newID("sub")
+	sub := &mollie.Subscription{
+		Resource:        "subscription",
+		ID:              id,
+		Mode:            mollie.TestMode,
+		Status:          mollie.SubscriptionStatusActive,
+		Amount:          cs.Amount,
+		Times:           cs.Times,
+		TimesRemaining:  cs.Times,
+		Interval:        cs.Interval,
+		Description:     cs.Description,
+		Method:          cs.Method,
+		MandateID:       m.ID,
+		WebhookURL:      cs.WebhookURL,
+		Metadata:        cs.Metadata,
+		StartDate:       start,
+		NextPaymentDate: start,
+		CreatedAT:       s.timestamp(),
+		Links: mollie.SubscriptionLinks{
+			Self:     s.link("/v2/customers/%s/subscriptions/%s", c.ID, id),
+			Customer: s.link("/v2/customers/%s", c.ID),
+			Payments: s.link("/v2/customers/%s/subscriptions/%s/payments", c.ID, id),
+		},
+	}
+
+	s.subscriptions.add(id, sub)
+	s.owners[id] = c.ID
+	writeJSON(w, http.StatusCreated, sub)
+}
+
+func (s *Server) listAllSubscriptions(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.subscriptions.list(nil)
+	writeList(w, r, s, "subscriptions", ids, items)
+}
+
+func (s *Server) listSubscriptions(w http.ResponseWriter, r *http.Request) {
+	c, ok := s.customer(w, r)
+	if !ok {
+		return
+	}
+
+	ids, items := s.subscriptions.list(func(sub *mollie.Subscription) bool { return s.owners[sub.ID] == c.ID })
+	writeList(w, r, s, "subscriptions", ids, items)
+}
+
+func (s *Server) subscription(w http.ResponseWriter, r *http.Request) (*mollie.Subscription, bool) {
+	sub, ok := s.subscriptions.get(r.PathValue("sub"))
+	if !ok || s.owners[sub.ID] != r.PathValue("id") {
+		writeNotFound(w, "subscription", r.PathValue("sub"))
+
+		return nil, false
+	}
+
+	return sub, true
+}
+
+func (s *Server) getSubscription(w http.ResponseWriter, r *http.Request) {
+	if sub, ok := s.subscription(w, r); ok {
+		writeJSON(w, http.StatusOK, sub)
+	}
+}
+
+func (s *Server) updateSubscription(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.subscription(w, r)
+	if !ok {
+		return
+	}
+
+	var us mollie.UpdateSubscription
+	if !decode(w, r, &us) {
+		return
+	}
+
+	if sub.Status != mollie.SubscriptionStatusActive {
+		writeError(w, http.StatusUnprocessableEntity, "The subscription is not active", "")
+
+		return
+	}
+
+	if us.Amount != nil {
+		if _, valid := cents(us.Amount); !valid {
+			writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+			return
+		}
+
+		sub.Amount = us.Amount
+	}
+
+	if us.Interval != "" {
+		if _, err := parseInterval(us.Interval); err != nil {
+			writeError(w, http.StatusUnprocessableEntity, "The interval is invalid", "interval")
+
+			return
+		}
+
+		sub.Interval = us.Interval
+	}
+
+	if us.Description != "" {
+		sub.Description = us.Description
+	}
+
+	if us.Times > 0 {
+		sub.TimesRemaining += us.Times - sub.Times
+		sub.Times = us.Times
+	}
+
+	if us.WebhookURL != "" {
+		sub.WebhookURL = us.WebhookURL
+	}
+
+	if us.Metadata != nil {
+		sub.Metadata = us.Metadata
+	}
+
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) cancelSubscription(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.subscription(w, r)
+	if !ok {
+		return
+	}
+
+	if sub.Status == mollie.SubscriptionStatusCanceled || sub.Status == mollie.SubscriptionStatusCompleted {
+		writeError(w, http.StatusUnprocessableEntity, "The subscription is already "+string(sub.Status), "")
+
+		return
+	}
+
+	sub.Status = mollie.SubscriptionStatusCanceled
+	sub.CanceledAt = s.timestamp()
+	sub.NextPaymentDate = nil
+	writeJSON(w, http.StatusOK, sub)
+}
+
+func (s *Server) listSubscriptionPayments(w http.ResponseWriter, r *http.Request) {
+	sub, ok := s.subscription(w, r)
+	if !ok {
+		return
+	}
+
+	ids, items := s.payments.list(func(p *mollie.Payment) bool { return p.SubscriptionID == sub.ID })
+	writeList(w, r, s, "payments", ids, items)
+}
+
+// ChargeSubscription simulates Mollie charging the next installment of
+// a subscription: a paid recurring payment is created and the next payment
+// date moves forward. The subscription completes after its last installment.
+//
+// It returns the ID of the new payment.
+func (s *Server) ChargeSubscription(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sub, ok := s.subscriptions.get(id)
+	if !ok {
+		return "", fmt.Errorf("mollietest: unknown subscription %s", id)
+	}
+
+	if sub.Status != mollie.SubscriptionStatusActive {
+		return "", fmt.Errorf("mollietest: subscription %s is %s", id, sub.Status)
+	}
+
+	customerID := s.owners[id]
+	if s.validMandate(customerID, sub.MandateID) == nil {
+		return "", fmt.Errorf("mollietest: mandate %s of subscription %s is not valid", sub.MandateID, id)
+	}
+
+	pid := s.newID("tr")
+	p := &mollie.Payment{
+		Resource:    "payment",
+		ID:          pid,
+		Mode:        mollie.TestMode,
+		Description: sub.Description,
+		Amount:      sub.Amount,
+		Method:      sub.Method,
+		WebhookURL:  sub.WebhookURL,
+		CreatedAt:   s.timestamp(),
+		Links: mollie.PaymentLinks{
+			Self: s.link("/v2/payments/%s", pid),
+		},
+		RecurrentPaymentFields: mollie.RecurrentPaymentFields{
+			SequenceType:   mollie.RecurringSequence,
+			CustomerID:     customerID,
+			MandateID:      sub.MandateID,
+			SubscriptionID: id,
+		},
+	}
+
+	s.payments.add(pid, p)
+	s.transitionPayment(p, PaymentPaid)
+
+	step, _ := parseInterval(sub.Interval)
+	next := step(sub.NextPaymentDate.Time)
+	sub.NextPaymentDate = &mollie.ShortDate{Time: next}
+
+	if sub.Times > 0 {
+		sub.TimesRemaining--
+		if sub.TimesRemaining == 0 {
+			sub.Status = mollie.SubscriptionStatusCompleted
+			sub.NextPaymentDate = nil
+		}
+	}
+
+	return pid, nil
+}
+
+// parseInterval parses subscription intervals like "1 month" or "14 days",
+// returning a function advancing a date by the interval.
+func parseInterval(interval string) (func(time.Time) time.Time, error) {
+	n, unit, ok := strings.Cut(strings.TrimSpace(interval), " ")
+	if !ok {
+		return nil, fmt.Errorf("invalid interval %q", interval)
+	}
+
+	count, err := strconv.Atoi(n)
+	if err != nil || count < 1 {
+		return nil, fmt.Errorf("invalid interval %q", interval)
+	}
+
+	switch strings.TrimSuffix(unit, "s") {
+	case "day":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, count) }, nil
+	case "week":
+		return func(t time.Time) time.Time { return t.AddDate(0, 0, 7*count) }, nil
+	case "month":
+		return func(t time.Time) time.Time { return t.AddDate(0, count, 0) }, nil
+	default:
+		return nil, fmt.Errorf("invalid interval %q", interval)
+	}
+}
@@ -0,0 +1,19 @@
+// Package mollietest provides an in-process fake of the Mollie API for
+// integration tests.
+//
+// The fake keeps payments, refunds, customers, mandates, subscriptions,
+// orders and payment links in memory, generates realistic IDs, paginates
+// lists like Mollie does, honours the Idempotency-Key header and allows
+// injecting errors:
+//
+//	srv := mollietest.NewServer()
+//	defer srv.Close()
+//
+//	client, _ := srv.Client()
+//	_, p, _ := client.Payments.Create(ctx, mollie.CreatePayment{...}, nil)
+//
+//	_ = srv.SetPaymentStatus(p.ID, mollietest.PaymentPaid)
+//
+// Status changes that happen outside the API in production, like a
+// consumer paying at the checkout, are triggered using the Server methods.
+package mollietest
@@ -0,0 +1,288 @@
+package mollietest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// orderTransitions lists the statuses an order can move to.
+var orderTransitions = map[mollie.OrderStatus][]mollie.OrderStatus{
+	mollie.Created:    {mollie.Paid, mollie.Authorized, mollie.Canceled, mollie.Expired},
+	mollie.Authorized: {mollie.Paid, mollie.Shipping, mollie.Completed, mollie.Canceled, mollie.Expired},
+	mollie.Paid:       {mollie.Shipping, mollie.Completed},
+	mollie.Shipping:   {mollie.Completed},
+}
+
+func (s *Server) routeOrders() {
+	s.mux.HandleFunc("POST /v2/orders", s.createOrder)
+	s.mux.HandleFunc("GET /v2/orders", s.listOrders)
+	s.mux.HandleFunc("GET /v2/orders/{id}", s.getOrder)
+	s.mux.HandleFunc("PATCH /v2/orders/{id}", s.updateOrder)
+	s.mux.HandleFunc("DELETE /v2/orders/{id}", s.cancelOrder)
+}
+
+// SetOrderStatus moves an order to a new status, e.g. after its payment
+// was completed or once it was shipped.
+//
+// Changing the status of the payment created with the order using
+// SetPaymentStatus updates the order accordingly.
+func (s *Server) SetOrderStatus(id string, status mollie.OrderStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.orders.get(id)
+	if !ok {
+		return fmt.Errorf("mollietest: unknown order %s", id)
+	}
+
+	if !slices.Contains(orderTransitions[o.Status], status) {
+		return fmt.Errorf("mollietest: order %s can not move from %s to %s", id, o.Status, status)
+	}
+
+	s.transitionOrder(o, status)
+
+	return nil
+}
+
+func (s *Server) transitionOrder(o *mollie.Order, status mollie.OrderStatus) {
+	now := s.timestamp()
+
+	o.Status = status
+	o.IsCancelable = status == mollie.Created || status == mollie.Authorized
+
+	lineStatus := mollie.OrderLineStatus(status)
+
+	switch status {
+	case mollie.Paid:
+		o.PaidAt = now
+		o.Links.Checkout = nil
+	case mollie.Authorized:
+		o.AuthorizedAt = now
+		o.Links.Checkout = nil
+	case mollie.Canceled:
+		o.CanceledAt = now
+	case mollie.Completed:
+		o.CompletedAt = now
+	case mollie.Expired:
+		o.ExpiredAt = now
+		lineStatus = mollie.OrderLineCanceled
+	}
+
+	for _, l := range o.Lines {
+		l.Status = lineStatus
+		l.IsCancelable = o.IsCancelable
+	}
+}
+
+// syncOrder updates an order after a change of the status of its payment.
+func (s *Server) syncOrder(o *mollie.Order, paymentStatus mollie.PaymentStatus) {
+	var status mollie.OrderStatus
+
+	switch paymentStatus {
+	case PaymentPaid:
+		status = mollie.Paid
+	case PaymentAuthorized:
+		status = mollie.Authorized
+	case PaymentExpired:
+		status = mollie.Expired
+	default:
+		return
+	}
+
+	if slices.Contains(orderTransitions[o.Status], status) {
+		s.transitionOrder(o, status)
+	}
+}
+
+func (s *Server) createOrder(w http.ResponseWriter, r *http.Request) {
+	var co mollie.CreateOrder
+	if !decode(w, r, &co) {
+		return
+	}
+
+	total, valid := cents(co.Amount)
+	if !valid {
+		writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+		return
+	}
+
+	if co.OrderNumber == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The order number is invalid", "orderNumber")
+
+		return
+	}
+
+	if len(co.Lines) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "The order must contain at least one line", "lines")
+
+		return
+	}
+
+	var sum int64
+
+	for _, l := range co.Lines {
+		v, ok := cents(l.TotalAmount)
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "The line total amount is invalid", "lines.totalAmount")
+
+			return
+		}
+
+		sum += v
+	}
+
+	if sum != total {
+		writeError(w, http.StatusUnprocessableEntity,
+			"The amount of the order does not match the total amount of the order lines", "amount")
+
+		return
+	}
+
+	id := s.newID("ord")
+	o := &mollie.Order{
+		Resource:        "order",
+		ID:              id,
+		Mode:            mollie.TestMode,
+		Status:          mollie.Created,
+		IsCancelable:    true,
+		OrderNumber:     co.OrderNumber,
+		Amount:          co.Amount,
+		RedirectURL:     co.RedirectURL,
+		CancelURL:       co.CancelURL,
+		WebhookURL:      co.WebhookURL,
+		BillingAddress:  co.BillingAddress,
+		ShippingAddress: co.ShippingAddress,
+		Locale:          co.Locale,
+		Metadata:        co.Metadata,
+		CreatedAt:       s.timestamp(),
+		Links: mollie.OrderLinks{
+			Self:     s.link("/v2/orders/%s", id),
+			Checkout: s.link("/checkout/%s", id),
+		},
+	}
+
+	for _, l := range co.Lines {
+		line := l
+		line.Resource = "orderline"
+		line.ID = s.newID("odl")
+		line.OrderID = id
+		line.Status = mollie.OrderLineCreated
+		line.IsCancelable = true
+		line.CreatedAt = o.CreatedAt
+		o.Lines = append(o.Lines, &line)
+	}
+
+	// Mollie creates the payment of the order right away.
+	p, _ := s.newPayment(w, &mollie.CreatePayment{Amount: co.Amount, Description: "Order " + co.OrderNumber})
+	p.OrderID = id
+	p.Links.Checkout = nil
+
+	s.orders.add(id, o)
+	writeJSON(w, http.StatusCreated, s.renderOrder(r, o))
+}
+
+// renderOrder embeds the payments of the order when requested.
+func (s *Server) renderOrder(r *http.Request, o *mollie.Order) *mollie.Order {
+	if !strings.Contains(r.URL.Query().Get("embed"), "payments") {
+		return o
+	}
+
+	out := *o
+	_, out.Embedded.Payments = s.payments.list(func(p *mollie.Payment) bool { return p.OrderID == o.ID })
+
+	return &out
+}
+
+func (s *Server) order(w http.ResponseWriter, r *http.Request) (*mollie.Order, bool) {
+	o, ok := s.orders.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "order", r.PathValue("id"))
+	}
+
+	return o, ok
+}
+
+func (s *Server) listOrders(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.orders.list(nil)
+	writeList(w, r, s, "orders", ids, items)
+}
+
+func (s *Server) getOrder(w http.ResponseWriter, r *http.Request) {
+	if o, ok := s.order(w, r); ok {
+		writeJSON(w, http.StatusOK, s.renderOrder(r, o))
+	}
+}
+
+func (s *Server) updateOrder(w http.ResponseWriter, r *http.Request) {
+	o, ok := s.order(w, r)
+	if !ok {
+		return
+	}
+
+	var uo mollie.UpdateOrder
+	if !decode(w, r, &uo) {
+		return
+	}
+
+	if o.Status == mollie.Canceled || o.Status == mollie.Expired || o.Status == mollie.Completed {
+		writeError(w, http.StatusUnprocessableEntity, "The order can not be updated anymore", "")
+
+		return
+	}
+
+	if uo.OrderNumber != "" {
+		o.OrderNumber = uo.OrderNumber
+	}
+
+	if uo.RedirectURL != "" {
+		o.RedirectURL = uo.RedirectURL
+	}
+
+	if uo.CancelURL != "" {
+		o.CancelURL = uo.CancelURL
+	}
+
+	if uo.WebhookURL != "" {
+		o.WebhookURL = uo.WebhookURL
+	}
+
+	if uo.BillingAddress != nil {
+		o.BillingAddress = uo.BillingAddress
+	}
+
+	if uo.ShippingAddress != nil {
+		o.ShippingAddress = uo.ShippingAddress
+	}
+
+	writeJSON(w, http.StatusOK, o)
+}
+
+func (s *Server) cancelOrder(w http.ResponseWriter, r *http.Request) {
+	o, ok := s.order(w, r)
+	if !ok {
+		return
+	}
+
+	if !o.IsCancelable {
+		writeError(w, http.StatusUnprocessableEntity, "The order can not be canceled", "")
+
+		return
+	}
+
+	s.transitionOrder(o, mollie.Canceled)
+
+	for _, p := range s.payments.items {
+		if p.OrderID == o.ID && p.IsCancelable {
+			p.Status = PaymentCanceled
+			p.IsCancelable = false
+			p.CanceledAt = o.CanceledAt
+		}
+	}
+
+	writeJSON(w, http.StatusOK, o)
+}
@@ -0,0 +1,166 @@
+package mollietest
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+func (s *Server) routePaymentLinks() {
+	s.mux.HandleFunc("POST /v2/payment-links", s.createPaymentLink)
+	s.mux.HandleFunc("GET /v2/payment-links", s.listPaymentLinks)
+	s.mux.HandleFunc("GET /v2/payment-links/{id}", s.getPaymentLink)
+	s.mux.HandleFunc("PATCH /v2/payment-links/{id}", s.updatePaymentLink)
+	s.mux.HandleFunc("DELETE /v2/payment-links/{id}", s.deletePaymentLink)
+	s.mux.HandleFunc("GET /v2/payment-links/{id}/payments", s.listPaymentLinkPayments)
+}
+
+// PayPaymentLink simulates a consumer paying a payment link: a paid payment
+// is created for the link, which can not be paid again.
+//
+// It returns the ID of the new payment.
+func (s *Server) PayPaymentLink(id string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	pl, ok := s.paymentLinks.get(id)
+	if !ok {
+		return "", fmt.Errorf("mollietest: unknown payment link %s", id)
+	}
+
+	if pl.PaidAt != nil {
+		return "", fmt.Errorf("mollietest: payment link %s is already paid", id)
+	}
+
+	if pl.ExpiresAt != nil && s.now().After(*pl.ExpiresAt) {
+		return "", fmt.Errorf("mollietest: payment link %s is expired", id)
+	}
+
+	amount := pl.Amount
+	pid := s.newID("tr")
+	p := &mollie.Payment{
+		Resource:    "payment",
+		ID:          pid,
+		Mode:        mollie.TestMode,
+		Description: pl.Description,
+		Amount:      &amount,
+		WebhookURL:  pl.WebhookURL,
+		CreatedAt:   s.timestamp(),
+		Links: mollie.PaymentLinks{
+			Self: s.link("/v2/payments/%s", pid),
+		},
+	}
+
+	s.payments.add(pid, p)
+	s.transitionPayment(p, PaymentPaid)
+	s.linkPayments[id] = append(s.linkPayments[id], pid)
+	pl.PaidAt = p.PaidAt
+
+	return pid, nil
+}
+
+func (s *Server) createPaymentLink(w http.ResponseWriter, r *http.Request) {
+	var in mollie.PaymentLink
+	if !decode(w, r, &in) {
+		return
+	}
+
+	if _, valid := cents(&in.Amount); !valid {
+		writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+		return
+	}
+
+	if in.Description == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The description is invalid", "description")
+
+		return
+	}
+
+	id := s.newID("pl")
+	pl := &mollie.PaymentLink{
+		Resource:    "payment-link",
+		ID:          id,
+		Mode:        mollie.TestMode,
+		Description: in.Description,
+		Amount:      in.Amount,
+		RedirectURL: in.RedirectURL,
+		WebhookURL:  in.WebhookURL,
+		ExpiresAt:   in.ExpiresAt,
+		CreatedAt:   s.timestamp(),
+		Links: mollie.PaymentLinkLinks{
+			Self:        s.link("/v2/payment-links/%s", id),
+			PaymentLink: s.link("/payment-links/%s", id),
+		},
+	}
+
+	s.paymentLinks.add(id, pl)
+	writeJSON(w, http.StatusCreated, pl)
+}
+
+func (s *Server) paymentLink(w http.ResponseWriter, r *http.Request) (*mollie.PaymentLink, bool) {
+	pl, ok := s.paymentLinks.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "payment link", r.PathValue("id"))
+	}
+
+	return pl, ok
+}
+
+func (s *Server) listPaymentLinks(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.paymentLinks.list(nil)
+	writeList(w, r, s, "payment_links", ids, items)
+}
+
+func (s *Server) getPaymentLink(w http.ResponseWriter, r *http.Request) {
+	if pl, ok := s.paymentLink(w, r); ok {
+		writeJSON(w, http.StatusOK, pl)
+	}
+}
+
+func (s *Server) updatePaymentLink(w http.ResponseWriter, r *http.Request) {
+	pl, ok := s.paymentLink(w, r)
+	if !ok {
+		return
+	}
+
+	var up mollie.UpdatePaymentLinks
+	if !decode(w, r, &up) {
+		return
+	}
+
+	if up.Description != "" {
+		pl.Description = up.Description
+	}
+
+	pl.UpdatedAt = s.timestamp()
+	writeJSON(w, http.StatusOK, pl)
+}
+
+func (s *Server) deletePaymentLink(w http.ResponseWriter, r *http.Request) {
+	pl, ok := s.paymentLink(w, r)
+	if !ok {
+		return
+	}
+
+	if len(s.linkPayments[pl.ID]) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Payment links with payments can not be deleted", "")
+
+		return
+	}
+
+	s.paymentLinks.remove(pl.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) listPaymentLinkPayments(w http.ResponseWriter, r *http.Request) {
+	pl, ok := s.paymentLink(w, r)
+	if !ok {
+		return
+	}
+
+	ids, items := s.payments.list(func(p *mollie.Payment) bool { return slices.Contains(s.linkPayments[pl.ID], p.ID) })
+	writeList(w, r, s, "payments", ids, items)
+}
@@ -0,0 +1,242 @@
+package mollietest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// Payment statuses supported by the fake server.
+const (
+	PaymentOpen       = mollie.PaymentStatusOpen
+	PaymentPending    = mollie.PaymentStatusPending
+	PaymentAuthorized = mollie.PaymentStatusAuthorized
+	PaymentPaid       = mollie.PaymentStatusPaid
+	PaymentFailed     = mollie.PaymentStatusFailed
+	PaymentCanceled   = mollie.PaymentStatusCanceled
+	PaymentExpired    = mollie.PaymentStatusExpired
+)
+
+func (s *Server) routePayments() {
+	s.mux.HandleFunc("POST /v2/payments", s.createPayment)
+	s.mux.HandleFunc("GET /v2/payments", s.listPayments)
+	s.mux.HandleFunc("GET /v2/payments/{id}", s.getPayment)
+	s.mux.HandleFunc("PATCH /v2/payments/{id}", s.updatePayment)
+	s.mux.HandleFunc("DELETE /v2/payments/{id}", s.cancelPayment)
+}
+
+// SetPaymentStatus moves a payment to a new status, simulating the actions
+// of the consumer or the payment method, e.g. paying at the checkout.
+//
+// Only the transitions allowed by Mollie are accepted: final statuses
+// can not be left, and an authorized payment can not fail.
+func (s *Server) SetPaymentStatus(id string, status mollie.PaymentStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p, ok := s.payments.get(id)
+	if !ok {
+		return fmt.Errorf("mollietest: unknown payment %s", id)
+	}
+
+	if err := mollie.ValidatePaymentTransition(p.Status, status); err != nil {
+		return fmt.Errorf("mollietest: payment %s: %w", id, err)
+	}
+
+	s.transitionPayment(p, status)
+
+	return nil
+}
+
+func (s *Server) transitionPayment(p *mollie.Payment, status mollie.PaymentStatus) {
+	now := s.timestamp()
+
+	p.Status = status
+	p.IsCancelable = false
+
+	switch status {
+	case PaymentPending, PaymentAuthorized:
+		p.IsCancelable = true
+		if status == PaymentAuthorized {
+			p.AuthorizedAt = now
+		}
+	case PaymentPaid:
+		p.PaidAt = now
+		p.AmountRefunded = amount(p.Amount.Currency, 0)
+		p.AmountRemaining = p.Amount
+		p.Links.Checkout = nil
+	case PaymentFailed:
+		p.FailedAt = now
+	case PaymentCanceled:
+		p.CanceledAt = now
+	case PaymentExpired:
+		p.ExpiredAt = now
+	}
+
+	if p.OrderID != "" {
+		if o, ok := s.orders.get(p.OrderID); ok {
+			s.syncOrder(o, status)
+		}
+	}
+}
+
+// newPayment validates the payload and stores a new open payment.
+func (s *Server) newPayment(w http.ResponseWriter, cp *mollie.CreatePayment) (*mollie.Payment, bool) {
+	if _, ok := cents(cp.Amount); !ok {
+		writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+		return nil, false
+	}
+
+	if cp.Description == "" {
+		writeError(w, http.StatusUnprocessableEntity, "The description is invalid", "description")
+
+		return nil, false
+	}
+
+	id := s.newID("tr")
+	p := &mollie.Payment{
+		Resource:     "payment",
+		ID:           id,
+		Mode:         mollie.TestMode,
+		Status:       PaymentOpen,
+		IsCancelable: true,
+		Description:  cp.Description,
+		CancelURL:    cp.CancelURL,
+		WebhookURL:   cp.WebhookURL,
+		Locale:       cp.Locale,
+		Metadata:     cp.Metadata,
+		Amount:       cp.Amount,
+		CreatedAt:    s.timestamp(),
+		Links: mollie.PaymentLinks{
+			Self:     s.link("/v2/payments/%s", id),
+			Checkout: s.link("/checkout/%s", id),
+		},
+		RecurrentPaymentFields: mollie.RecurrentPaymentFields{
+			SequenceType: cp.SequenceType,
+			CustomerID:   cp.CustomerID,
+			MandateID:    cp.MandateID,
+		},
+	}
+
+	if len(cp.Method) == 1 {
+		p.Method = cp.Method[0]
+	}
+
+	s.payments.add(id, p)
+
+	return p, true
+}
+
+func (s *Server) createPayment(w http.ResponseWriter, r *http.Request) {
+	var cp mollie.CreatePayment
+	if !decode(w, r, &cp) {
+		return
+	}
+
+	if cp.CustomerID != "" {
+		if _, ok := s.customers.get(cp.CustomerID); !ok {
+			writeError(w, http.StatusUnprocessableEntity, "The customer id is invalid", "customerId")
+
+			return
+		}
+	}
+
+	recurring := cp.SequenceType == mollie.RecurringSequence
+	if recurring && s.validMandate(cp.CustomerID, cp.MandateID) == nil {
+		writeError(w, http.StatusUnprocessableEntity, "The customer does not have a valid mandate", "customerId")
+
+		return
+	}
+
+	p, ok := s.newPayment(w, &cp)
+	if !ok {
+		return
+	}
+
+	if recurring {
+		// Recurring payments are charged without a checkout.
+		p.MandateID = s.validMandate(cp.CustomerID, cp.MandateID).ID
+		p.Links.Checkout = nil
+		s.transitionPayment(p, PaymentPending)
+	}
+
+	writeJSON(w, http.StatusCreated, p)
+}
+
+func (s *Server) listPayments(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.payments.list(nil)
+	writeList(w, r, s, "payments", ids, items)
+}
+
+func (s *Server) getPayment(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.payments.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "payment", r.PathValue("id"))
+
+		return
+	}
+
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) updatePayment(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.payments.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "payment", r.PathValue("id"))
+
+		return
+	}
+
+	var up mollie.UpdatePayment
+	if !decode(w, r, &up) {
+		return
+	}
+
+	if p.Status != PaymentOpen {
+		writeError(w, http.StatusUnprocessableEntity, "The payment can not be updated anymore", "")
+
+		return
+	}
+
+	if up.Description != "" {
+		p.Description = up.Description
+	}
+
+	if up.CancelURL != "" {
+		p.CancelURL = up.CancelURL
+	}
+
+	if up.WebhookURL != "" {
+		p.WebhookURL = up.WebhookURL
+	}
+
+	if up.Metadata != nil {
+		p.Metadata = up.Metadata
+	}
+
+	if up.Locale != "" {
+		p.Locale = up.Locale
+	}
+
+	writeJSON(w, http.StatusOK, p)
+}
+
+func (s *Server) cancelPayment(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.payments.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "payment", r.PathValue("id"))
+
+		return
+	}
+
+	if !p.IsCancelable {
+		writeError(w, http.StatusUnprocessableEntity, "The payment can not be canceled", "")
+
+		return
+	}
+
+	s.transitionPayment(p, PaymentCanceled)
+	writeJSON(w, http.StatusOK, p)
+}
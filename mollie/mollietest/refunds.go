@@ -0,0 +1,170 @@
+package mollietest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+func (s *Server) routeRefunds() {
+	s.mux.HandleFunc("GET /v2/refunds", s.listRefunds)
+	s.mux.HandleFunc("POST /v2/payments/{id}/refunds", s.createRefund)
+	s.mux.HandleFunc("GET /v2/payments/{id}/refunds", s.listPaymentRefunds)
+	s.mux.HandleFunc("GET /v2/payments/{id}/refunds/{refund}", s.getRefund)
+	s.mux.HandleFunc("DELETE /v2/payments/{id}/refunds/{refund}", s.cancelRefund)
+}
+
+// SetRefundStatus moves a refund to a new status, simulating its processing
+// by Mollie. Failed refunds give the refunded amount back to the payment.
+func (s *Server) SetRefundStatus(id string, status mollie.RefundStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	rf, ok := s.refunds.get(id)
+	if !ok {
+		return fmt.Errorf("mollietest: unknown refund %s", id)
+	}
+
+	if rf.Status == mollie.Refunded || rf.Status == mollie.Failed {
+		return fmt.Errorf("mollietest: refund %s is already %s", id, rf.Status)
+	}
+
+	if status == mollie.Failed {
+		s.restoreRefund(rf)
+	}
+
+	rf.Status = status
+
+	return nil
+}
+
+func (s *Server) restoreRefund(rf *mollie.Refund) {
+	p, ok := s.payments.get(rf.PaymentID)
+	if !ok {
+		return
+	}
+
+	refunded, _ := cents(p.AmountRefunded)
+	remaining, _ := cents(p.AmountRemaining)
+	value, _ := cents(rf.Amount)
+
+	p.AmountRefunded = amount(p.Amount.Currency, refunded-value)
+	p.AmountRemaining = amount(p.Amount.Currency, remaining+value)
+}
+
+func (s *Server) createRefund(w http.ResponseWriter, r *http.Request) {
+	p, ok := s.payments.get(r.PathValue("id"))
+	if !ok {
+		writeNotFound(w, "payment", r.PathValue("id"))
+
+		return
+	}
+
+	var cr mollie.CreatePaymentRefund
+	if !decode(w, r, &cr) {
+		return
+	}
+
+	if p.Status != PaymentPaid {
+		writeError(w, http.StatusUnprocessableEntity, "The payment can not be refunded, it is not paid", "")
+
+		return
+	}
+
+	remaining, _ := cents(p.AmountRemaining)
+	refunded, _ := cents(p.AmountRefunded)
+
+	value := remaining
+	if cr.Amount != nil {
+		v, valid := cents(cr.Amount)
+		if !valid || v == 0 || cr.Amount.Currency != p.Amount.Currency {
+			writeError(w, http.StatusUnprocessableEntity, "The amount is invalid", "amount")
+
+			return
+		}
+
+		value = v
+	}
+
+	if value == 0 || value > remaining {
+		writeError(w, http.StatusUnprocessableEntity,
+			"The amount is higher than the amount that remains to be refunded", "amount")
+
+		return
+	}
+
+	p.AmountRefunded = amount(p.Amount.Currency, refunded+value)
+	p.AmountRemaining = amount(p.Amount.Currency, remaining-value)
+
+	id := s.newID("re")
+	rf := &mollie.Refund{
+		Resource:    "refund",
+		ID:          id,
+		PaymentID:   p.ID,
+		OrderID:     p.OrderID,
+		Description: cr.Description,
+		Metadata:    cr.Metadata,
+		Amount:      amount(p.Amount.Currency, value),
+		Status:      mollie.Pending,
+		CreatedAt:   s.timestamp(),
+		Links: mollie.RefundLinks{
+			Self:    s.link("/v2/payments/%s/refunds/%s", p.ID, id),
+			Payment: s.link("/v2/payments/%s", p.ID),
+		},
+	}
+
+	s.refunds.add(id, rf)
+	writeJSON(w, http.StatusCreated, rf)
+}
+
+func (s *Server) listRefunds(w http.ResponseWriter, r *http.Request) {
+	ids, items := s.refunds.list(nil)
+	writeList(w, r, s, "refunds", ids, items)
+}
+
+func (s *Server) listPaymentRefunds(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if _, ok := s.payments.get(id); !ok {
+		writeNotFound(w, "payment", id)
+
+		return
+	}
+
+	ids, items := s.refunds.list(func(rf *mollie.Refund) bool { return rf.PaymentID == id })
+	writeList(w, r, s, "refunds", ids, items)
+}
+
+func (s *Server) paymentRefund(w http.ResponseWriter, r *http.Request) (*mollie.Refund, bool) {
+	rf, ok := s.refunds.get(r.PathValue("refund"))
+	if !ok || rf.PaymentID != r.PathValue("id") {
+		writeNotFound(w, "refund", r.PathValue("refund"))
+
+		return nil, false
+	}
+
+	return rf, true
+}
+
+func (s *Server) getRefund(w http.ResponseWriter, r *http.Request) {
+	if rf, ok := s.paymentRefund(w, r); ok {
+		writeJSON(w, http.StatusOK, rf)
+	}
+}
+
+func (s *Server) cancelRefund(w http.ResponseWriter, r *http.Request) {
+	rf, ok := s.paymentRefund(w, r)
+	if !ok {
+		return
+	}
+
+	if rf.Status != mollie.Queued && rf.Status != mollie.Pending {
+		writeError(w, http.StatusUnprocessableEntity, "The refund can not be canceled anymore", "")
+
+		return
+	}
+
+	s.restoreRefund(rf)
+	s.refunds.remove(rf.ID)
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,375 @@
+package mollietest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// Pagination limits applied by Mollie to list endpoints.
+const (
+	DefaultLimit = 50
+	MaxLimit     = 250
+)
+
+// Token is the API token used by the clients returned by Server.Client.
+const Token = "test_mollietest0000000000000000"
+
+const idAlphabet = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+
+// Server is a fake Mollie API backed by an in-memory store.
+//
+// All the requests are handled sequentially, so the state observed by
+// a request is never modified while it is being handled.
+type Server struct {
+	*httptest.Server
+
+	mu  sync.Mutex
+	mux *http.ServeMux
+	now func() time.Time
+
+	payments      *store[mollie.Payment]
+	refunds       *store[mollie.Refund]
+	customers     *store[mollie.Customer]
+	mandates      *store[mollie.Mandate]
+	subscriptions *store[mollie.Subscription]
+	orders        *store[mollie.Order]
+	paymentLinks  *store[mollie.PaymentLink]
+
+	// owners maps mandates and subscriptions to their customer.
+	owners map[string]string
+	// linkPayments maps payment links to the payments created through them.
+	linkPayments map[string][]string
+
+	idempotent map[string]*recorded
+	faults     []*fault
+}
+
+type recorded struct {
+	method string
+	path   string
+	digest [sha256.Size]byte
+	status int
+	body   []byte
+}
+
+type fault struct {
+	method string
+	prefix string
+	status int
+	times  int
+}
+
+// NewServer starts and returns a new fake Mollie API,
+// the caller should call Close when finished.
+func NewServer() *Server {
+	s := &Server{
+		mux:           http.NewServeMux(),
+		now:           time.Now,
+		payments:      newStore[mollie.Payment](),
+		refunds:       newStore[mollie.Refund](),
+		customers:     newStore[mollie.Customer](),
+		mandates:      newStore[mollie.Mandate](),
+		subscriptions: newStore[mollie.Subscription](),
+		orders:        newStore[mollie.Order](),
+		paymentLinks:  newStore[mollie.PaymentLink](),
+		owners:        make(map[string]string),
+		linkPayments:  make(map[string][]string),
+		idempotent:    make(map[string]*recorded),
+	}
+
+	s.routePayments()
+	s.routeRefunds()
+	s.routeCustomers()
+	s.routeOrders()
+	s.routePaymentLinks()
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+
+	return s
+}
+
+// Client returns a Mollie client sending its requests to the fake server.
+func (s *Server) Client() (*mollie.Client, error) {
+	c, err := mollie.NewClient(s.Server.Client(), mollie.NewAPITestingConfig(true))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.WithAuthenticationValue(Token); err != nil {
+		return nil, err
+	}
+
+	c.BaseURL, err = url.Parse(s.URL + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// InjectError makes the next times requests matching method and path prefix
+// fail with the given status code, a times value of zero or less injects
+// the error until ClearErrors is called. An empty method matches any method.
+func (s *Server) InjectError(method, pathPrefix string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = append(s.faults, &fault{method, pathPrefix, status, times})
+}
+
+// ClearErrors removes all the injected errors.
+func (s *Server) ClearErrors() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.faults = nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !strings.HasPrefix(r.Header.Get(mollie.AuthHeader), mollie.TokenType+" ") {
+		writeError(w, http.StatusUnauthorized, "Missing authentication, or failed to authenticate", "")
+
+		return
+	}
+
+	if s.injectFault(w, r) {
+		return
+	}
+
+	key := r.Header.Get(mollie.IdempotencyKeyHeader)
+	if key == "" || r.Method == http.MethodGet {
+		s.mux.ServeHTTP(w, r)
+
+		return
+	}
+
+	body, _ := io.ReadAll(r.Body)
+	r.Body = io.NopCloser(bytes.NewReader(body))
+	digest := sha256.Sum256(body)
+
+	if prev, ok := s.idempotent[key]; ok {
+		if prev.method != r.Method || prev.path != r.URL.Path || prev.digest != digest {
+			writeError(w, http.StatusUnprocessableEntity,
+				"The idempotency key was already used for a different request", "")
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/hal+json")
+		w.WriteHeader(prev.status)
+		_, _ = w.Write(prev.body)
+
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	s.mux.ServeHTTP(rec, r)
+
+	// Server errors are not stored so that the request can be retried with
+	// the same key, other responses, client errors included, are replayed.
+	if rec.Code < http.StatusInternalServerError {
+		s.idempotent[key] = &recorded{r.Method, r.URL.Path, digest, rec.Code, rec.Body.Bytes()}
+	}
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+
+	w.WriteHeader(rec.Code)
+	_, _ = w.Write(rec.Body.Bytes())
+}
+
+func (s *Server) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	for i, f := range s.faults {
+		if (f.method != "" && f.method != r.Method) || !strings.HasPrefix(r.URL.Path, f.prefix) {
+			continue
+		}
+
+		if f.times > 0 {
+			f.times--
+			if f.times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+
+		if f.status == http.StatusTooManyRequests {
+			w.Header().Set("Retry-After", "1")
+		}
+
+		writeError(w, f.status, "Injected error", "")
+
+		return true
+	}
+
+	return false
+}
+
+func (s *Server) newID(prefix string) string {
+	b := make([]byte, 10)
+	for i := range b {
+		b[i] = idAlphabet[rand.IntN(len(idAlphabet))] //nolint:gosec // IDs do not need to be unpredictable.
+	}
+
+	return prefix + "_" + string(b)
+}
+
+func (s *Server) timestamp() *time.Time {
+	t := s.now().UTC().Truncate(time.Second)
+
+	return &t
+}
+
+func (s *Server) link(format string, args ...any) *mollie.URL {
+	return &mollie.URL{Href: s.URL + fmt.Sprintf(format, args...), Type: "application/hal+json"}
+}
+
+// store keeps resources in creation order.
+type store[T any] struct {
+	ids   []string
+	items map[string]*T
+}
+
+func newStore[T any]() *store[T] {
+	return &store[T]{items: make(map[string]*T)}
+}
+
+func (st *store[T]) add(id string, v *T) {
+	st.ids = append(st.ids, id)
+	st.items[id] = v
+}
+
+func (st *store[T]) get(id string) (*T, bool) {
+	v, ok := st.items[id]
+
+	return v, ok
+}
+
+func (st *store[T]) remove(id string) {
+	delete(st.items, id)
+
+	for i, v := range st.ids {
+		if v == id {
+			st.ids = append(st.ids[:i], st.ids[i+1:]...)
+
+			break
+		}
+	}
+}
+
+// list returns the resources matching keep from newest to oldest.
+func (st *store[T]) list(keep func(*T) bool) (ids []string, items []*T) {
+	for i := len(st.ids) - 1; i >= 0; i-- {
+		v := st.items[st.ids[i]]
+		if keep == nil || keep(v) {
+			ids = append(ids, st.ids[i])
+			items = append(items, v)
+		}
+	}
+
+	return ids, items
+}
+
+// writeList writes a paginated list response, the from query parameter
+// is the ID of the first resource of the page.
+func writeList[T any](w http.ResponseWriter, r *http.Request, s *Server, key string, ids []string, items []*T) {
+	limit := DefaultLimit
+
+	if l := r.URL.Query().Get("limit"); l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 1 || n > MaxLimit {
+			writeError(w, http.StatusBadRequest, "The limit is invalid", "limit")
+
+			return
+		}
+
+		limit = n
+	}
+
+	start := 0
+
+	if from := r.URL.Query().Get("from"); from != "" {
+		start = -1
+
+		for i, id := range ids {
+			if id == from {
+				start = i
+
+				break
+			}
+		}
+
+		if start < 0 {
+			writeError(w, http.StatusBadRequest, "The from parameter is invalid", "from")
+
+			return
+		}
+	}
+
+	end := min(start+limit, len(items))
+	page := items[start:end]
+
+	links := mollie.PaginationLinks{
+		Self: s.link("%s?%s", r.URL.Path, r.URL.RawQuery),
+	}
+
+	if end < len(items) {
+		links.Next = s.link("%s?from=%s&limit=%d", r.URL.Path, ids[end], limit)
+	}
+
+	if start > 0 {
+		links.Previous = s.link("%s?from=%s&limit=%d", r.URL.Path, ids[max(start-limit, 0)], limit)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"count":     len(page),
+		"_embedded": map[string]any{key: page},
+		"_links":    links,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, detail, field string) {
+	w.Header().Set("Content-Type", "application/hal+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(mollie.BaseError{
+		Status: status,
+		Title:  http.StatusText(status),
+		Detail: detail,
+		Field:  field,
+	})
+}
+
+func writeNotFound(w http.ResponseWriter, resource, id string) {
+	writeError(w, http.StatusNotFound, fmt.Sprintf("No %s exists with token %s.", resource, id), "")
+}
+
+func decode(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		writeError(w, http.StatusBadRequest, "The request body is not valid JSON", "")
+
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,316 @@
+package mollietest
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T) (*Server, *mollie.Client) {
+	t.Helper()
+
+	srv := NewServer()
+	t.Cleanup(srv.Close)
+
+	client, err := srv.Client()
+	require.Nil(t, err)
+
+	return srv, client
+}
+
+func eur(v string) *mollie.Amount {
+	return &mollie.Amount{Currency: "EUR", Value: v}
+}
+
+func TestServer_PaymentLifecycle(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, p, err := client.Payments.Create(ctx, mollie.CreatePayment{Amount: eur("10.00"), Description: "Order #1"}, nil)
+	require.Nil(t, err)
+	assert.Regexp(t, `^tr_[a-zA-Z0-9]{10}$`, p.ID)
+	assert.Equal(t, PaymentOpen, p.Status)
+	assert.True(t, p.IsCancelable)
+	assert.NotNil(t, p.Links.Checkout)
+
+	_, _, err = client.Refunds.CreatePaymentRefund(ctx, p.ID, mollie.CreatePaymentRefund{}, nil)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	require.Nil(t, srv.SetPaymentStatus(p.ID, PaymentPaid))
+	assert.NotNil(t, srv.SetPaymentStatus(p.ID, PaymentFailed))
+
+	_, _, err = client.Payments.Cancel(ctx, p.ID)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, rf, err := client.Refunds.CreatePaymentRefund(ctx, p.ID, mollie.CreatePaymentRefund{Amount: eur("4.00")}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, mollie.Pending, rf.Status)
+
+	_, p, err = client.Payments.Get(ctx, p.ID, nil)
+	require.Nil(t, err)
+	assert.Equal(t, PaymentPaid, p.Status)
+	assert.Equal(t, "6.00", p.AmountRemaining.Value)
+	assert.Equal(t, "4.00", p.AmountRefunded.Value)
+
+	_, _, err = client.Refunds.CreatePaymentRefund(ctx, p.ID, mollie.CreatePaymentRefund{Amount: eur("6.01")}, nil)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, err = client.Refunds.CancelPaymentRefund(ctx, p.ID, rf.ID)
+	require.Nil(t, err)
+
+	_, p, err = client.Payments.Get(ctx, p.ID, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "10.00", p.AmountRemaining.Value)
+
+	_, _, err = client.Payments.Get(ctx, "tr_unknown", nil)
+	assert.ErrorIs(t, err, mollie.ErrNotFound)
+}
+
+func TestServer_CancelPayment(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, p, err := client.Payments.Create(ctx, mollie.CreatePayment{Amount: eur("1.00"), Description: "x"}, nil)
+	require.Nil(t, err)
+
+	_, p, err = client.Payments.Cancel(ctx, p.ID)
+	require.Nil(t, err)
+	assert.Equal(t, PaymentCanceled, p.Status)
+	assert.NotNil(t, p.CanceledAt)
+
+	_, _, err = client.Payments.Create(ctx, mollie.CreatePayment{Description: "no amount"}, nil)
+
+	var be *mollie.BaseError
+	require.ErrorAs(t, err, &be)
+	assert.Equal(t, "amount", be.Field)
+}
+
+func TestServer_Pagination(t *testing.T) {
+	_, client := newTestClient(t)
+	ctx := context.Background()
+
+	var created []string
+
+	for range 5 {
+		_, p, err := client.Payments.Create(ctx, mollie.CreatePayment{Amount: eur("1.00"), Description: "x"}, nil)
+		require.Nil(t, err)
+
+		created = append([]string{p.ID}, created...)
+	}
+
+	_, page, err := client.Payments.List(ctx, &mollie.ListPaymentsOptions{Limit: 2})
+	require.Nil(t, err)
+	assert.Equal(t, 2, page.Count)
+	require.NotNil(t, page.Links.Next)
+
+	var all []string
+
+	for p, err := range client.Payments.All(ctx, &mollie.ListPaymentsOptions{Limit: 2}) {
+		require.Nil(t, err)
+
+		all = append(all, p.ID)
+	}
+
+	assert.Equal(t, created, all)
+}
+
+func TestServer_Idempotency(t *testing.T) {
+	_, client := newTestClient(t)
+
+	ctx := mollie.WithIdempotencyKey(context.Background(), "order-1")
+	cp := mollie.CreatePayment{Amount: eur("1.00"), Description: "x"}
+
+	_, first, err := client.Payments.Create(ctx, cp, nil)
+	require.Nil(t, err)
+
+	_, second, err := client.Payments.Create(ctx, cp, nil)
+	require.Nil(t, err)
+	assert.Equal(t, first.ID, second.ID)
+
+	cp.Description = "different"
+	_, _, err = client.Payments.Create(ctx, cp, nil)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, list, err := client.Payments.List(context.Background(), nil)
+	require.Nil(t, err)
+	assert.Equal(t, 1, list.Count)
+}
+
+func TestServer_InjectError(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	srv.InjectError(http.MethodGet, "/v2/payments", http.StatusServiceUnavailable, 1)
+
+	_, _, err := client.Payments.List(ctx, nil)
+	assert.ErrorIs(t, err, mollie.ErrServer)
+
+	var injected *mollie.BaseError
+	require.ErrorAs(t, err, &injected)
+	assert.Equal(t, http.StatusText(http.StatusServiceUnavailable), injected.Title)
+	assert.Equal(t, "Injected error", injected.Detail)
+	assert.Empty(t, injected.Field)
+
+	_, _, err = client.Payments.List(ctx, nil)
+	assert.Nil(t, err)
+
+	srv.InjectError("", "/v2/customers", http.StatusTooManyRequests, 0)
+
+	for range 2 {
+		_, _, err = client.Customers.List(ctx, nil)
+
+		var be *mollie.BaseError
+		require.ErrorAs(t, err, &be)
+		assert.Equal(t, http.StatusTooManyRequests, be.Status)
+		assert.Positive(t, be.RetryAfter)
+	}
+
+	srv.ClearErrors()
+
+	_, _, err = client.Customers.List(ctx, nil)
+	assert.Nil(t, err)
+}
+
+func TestServer_Subscriptions(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, c, err := client.Customers.Create(ctx, mollie.CreateCustomer{Name: "Jane", Email: "jane@example.org"})
+	require.Nil(t, err)
+
+	cs := mollie.CreateSubscription{Amount: eur("5.00"), Interval: "1 month", Description: "Plan", Times: 2}
+
+	_, _, err = client.Subscriptions.Create(ctx, c.ID, cs)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, m, err := client.Mandates.Create(ctx, c.ID, mollie.CreateMandate{
+		Method:          mollie.DirectDebit,
+		ConsumerName:    "Jane",
+		ConsumerAccount: "NL55INGB0000000000",
+	})
+	require.Nil(t, err)
+	assert.Equal(t, mollie.ValidMandate, m.Status)
+
+	_, sub, err := client.Subscriptions.Create(ctx, c.ID, cs)
+	require.Nil(t, err)
+	assert.Equal(t, mollie.SubscriptionStatusActive, sub.Status)
+	assert.Equal(t, m.ID, sub.MandateID)
+
+	start := sub.NextPaymentDate.Time
+
+	pid, err := srv.ChargeSubscription(sub.ID)
+	require.Nil(t, err)
+
+	_, sub, err = client.Subscriptions.Get(ctx, c.ID, sub.ID)
+	require.Nil(t, err)
+	assert.Equal(t, 1, sub.TimesRemaining)
+	assert.Equal(t, start.AddDate(0, 1, 0), sub.NextPaymentDate.Time)
+
+	_, payments, err := client.Subscriptions.ListPayments(ctx, c.ID, sub.ID, nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, payments.Count)
+	assert.Equal(t, pid, payments.Embedded.Payments[0].ID)
+	assert.Equal(t, PaymentPaid, payments.Embedded.Payments[0].Status)
+
+	_, err = srv.ChargeSubscription(sub.ID)
+	require.Nil(t, err)
+
+	_, sub, err = client.Subscriptions.Get(ctx, c.ID, sub.ID)
+	require.Nil(t, err)
+	assert.Equal(t, mollie.SubscriptionStatusCompleted, sub.Status)
+
+	_, _, err = client.Subscriptions.Cancel(ctx, c.ID, sub.ID)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, err = client.Mandates.Revoke(ctx, c.ID, m.ID)
+	require.Nil(t, err)
+
+	_, _, err = client.Subscriptions.Create(ctx, c.ID, cs)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	_, err = client.Customers.Delete(ctx, c.ID)
+	require.Nil(t, err)
+
+	_, _, err = client.Subscriptions.Get(ctx, c.ID, sub.ID)
+	assert.ErrorIs(t, err, mollie.ErrNotFound)
+}
+
+func TestServer_Orders(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	co := mollie.CreateOrder{
+		Amount:      eur("20.00"),
+		OrderNumber: "1337",
+		Lines: []mollie.OrderLine{
+			{Name: "LEGO", Quantity: 2, UnitPrice: eur("10.00"), TotalAmount: eur("20.00")},
+		},
+	}
+
+	_, o, err := client.Orders.Create(ctx, co, &mollie.OrderOptions{Embed: []mollie.EmbedValue{mollie.EmbedPayments}})
+	require.Nil(t, err)
+	assert.Equal(t, mollie.Created, o.Status)
+	require.Len(t, o.Embedded.Payments, 1)
+
+	require.Nil(t, srv.SetPaymentStatus(o.Embedded.Payments[0].ID, PaymentPaid))
+
+	_, o, err = client.Orders.Get(ctx, o.ID, nil)
+	require.Nil(t, err)
+	assert.Equal(t, mollie.Paid, o.Status)
+	assert.Equal(t, mollie.OrderLinePaid, o.Lines[0].Status)
+
+	_, _, err = client.Orders.Cancel(ctx, o.ID)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+
+	require.Nil(t, srv.SetOrderStatus(o.ID, mollie.Completed))
+	assert.NotNil(t, srv.SetOrderStatus(o.ID, mollie.Canceled))
+
+	co.Amount = eur("19.00")
+	_, _, err = client.Orders.Create(ctx, co, nil)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+}
+
+func TestServer_PaymentLinks(t *testing.T) {
+	srv, client := newTestClient(t)
+	ctx := context.Background()
+
+	_, pl, err := client.PaymentLinks.Create(ctx, mollie.PaymentLink{
+		Description: "Invoice 42",
+		Amount:      *eur("42.00"),
+	}, nil)
+	require.Nil(t, err)
+	assert.Regexp(t, `^pl_`, pl.ID)
+
+	pid, err := srv.PayPaymentLink(pl.ID)
+	require.Nil(t, err)
+
+	_, err = srv.PayPaymentLink(pl.ID)
+	assert.NotNil(t, err)
+
+	_, pl, err = client.PaymentLinks.Get(ctx, pl.ID)
+	require.Nil(t, err)
+	assert.NotNil(t, pl.PaidAt)
+
+	_, payments, err := client.PaymentLinks.Payments(ctx, pl.ID, nil)
+	require.Nil(t, err)
+	require.Equal(t, 1, payments.Count)
+	assert.Equal(t, pid, payments.Embedded.Payments[0].ID)
+
+	_, err = client.PaymentLinks.Delete(ctx, pl.ID)
+	assert.ErrorIs(t, err, mollie.ErrUnprocessable)
+}
+
+func TestServer_RequiresAuthentication(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	res, err := http.Get(srv.URL + "/v2/payments")
+	require.Nil(t, err)
+	defer res.Body.Close()
+
+	assert.Equal(t, http.StatusUnauthorized, res.StatusCode)
+}
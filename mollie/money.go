@@ -0,0 +1,325 @@
+package mollie
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Errors returned by the Money operations.
+var (
+	ErrCurrencyMismatch = errors.New("currency mismatch")
+	ErrInvalidMoney     = errors.New("invalid money value")
+)
+
+// currencyDecimals contains the ISO 4217 currencies whose minor unit
+// is not a hundredth of the major unit.
+var currencyDecimals = map[string]int{
+	"BIF": 0, "CLP": 0, "DJF": 0, "GNF": 0, "ISK": 0, "JPY": 0, "KMF": 0, "KRW": 0,
+	"PYG": 0, "RWF": 0, "UGX": 0, "UYI": 0, "VND": 0, "VUV": 0, "XAF": 0, "XOF": 0, "XPF": 0,
+	"BHD": 3, "IQD": 3, "JOD": 3, "KWD": 3, "LYD": 3, "OMR": 3, "TND": 3,
+}
+
+// CurrencyDecimals returns the number of decimals used by a currency
+// according to ISO 4217, e.g. 2 for EUR and 0 for JPY.
+func CurrencyDecimals(currency string) int {
+	if d, ok := currencyDecimals[currency]; ok {
+		return d
+	}
+
+	return 2
+}
+
+// Money is an exact monetary value, stored as an integer number of minor
+// units (e.g. cents) of its currency.
+//
+// Operations between values of different currencies fail with
+// ErrCurrencyMismatch instead of silently mixing them.
+type Money struct {
+	currency string
+	minor    int64
+}
+
+// NewMoney returns the Money value for a number of minor units of a currency,
+// e.g. NewMoney("EUR", 1050) is EUR 10.50.
+func NewMoney(currency string, minor int64) (Money, error) {
+	if !validCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: unknown currency %q", ErrInvalidMoney, currency)
+	}
+
+	return Money{currency: currency, minor: minor}, nil
+}
+
+// ParseMoney parses a decimal value like the ones returned by Mollie,
+// values can not have more decimals than allowed by the currency.
+func ParseMoney(currency, value string) (Money, error) {
+	if !validCurrency(currency) {
+		return Money{}, fmt.Errorf("%w: unknown currency %q", ErrInvalidMoney, currency)
+	}
+
+	minor, err := parseDecimal(value, CurrencyDecimals(currency))
+	if err != nil {
+		return Money{}, fmt.Errorf("%w: %s %q", ErrInvalidMoney, currency, value)
+	}
+
+	return Money{currency: currency, minor: minor}, nil
+}
+
+func validCurrency(currency string) bool {
+	if len(currency) != 3 {
+		return false
+	}
+
+	for _, r := range currency {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseDecimal parses value as a number of units of 10^-decimals.
+func parseDecimal(value string, decimals int) (int64, error) {
+	neg := strings.HasPrefix(value, "-")
+	units, frac, _ := strings.Cut(strings.TrimPrefix(value, "-"), ".")
+
+	if units == "" || len(frac) > decimals || strings.ContainsAny(units+frac, "+-") {
+		return 0, ErrInvalidMoney
+	}
+
+	n, err := strconv.ParseInt(units+frac+strings.Repeat("0", decimals-len(frac)), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	if neg {
+		n = -n
+	}
+
+	return n, nil
+}
+
+// Money converts the amount into an exact Money value.
+func (a Amount) Money() (Money, error) {
+	return ParseMoney(a.Currency, a.Value)
+}
+
+// Currency returns the ISO 4217 currency code.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// Minor returns the value in minor units of the currency.
+func (m Money) Minor() int64 {
+	return m.minor
+}
+
+// IsZero reports whether the value is zero.
+func (m Money) IsZero() bool {
+	return m.minor == 0
+}
+
+// IsNegative reports whether the value is lower than zero.
+func (m Money) IsNegative() bool {
+	return m.minor < 0
+}
+
+// Amount returns the value formatted as required by Mollie, e.g. EUR 10.00.
+func (m Money) Amount() *Amount {
+	return &Amount{Currency: m.currency, Value: m.String()}
+}
+
+// String returns the value with the number of decimals of its currency.
+func (m Money) String() string {
+	d := CurrencyDecimals(m.currency)
+
+	abs := m.minor
+	sign := ""
+
+	if abs < 0 {
+		abs = -abs
+		sign = "-"
+	}
+
+	if d == 0 {
+		return sign + strconv.FormatInt(abs, 10)
+	}
+
+	digits := fmt.Sprintf("%0*d", d+1, abs)
+
+	return sign + digits[:len(digits)-d] + "." + digits[len(digits)-d:]
+}
+
+// MarshalJSON encodes the value like an Amount.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Amount())
+}
+
+// UnmarshalJSON decodes an Amount into an exact value.
+func (m *Money) UnmarshalJSON(b []byte) error {
+	var a Amount
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+
+	v, err := a.Money()
+	if err != nil {
+		return err
+	}
+
+	*m = v
+
+	return nil
+}
+
+func (m Money) check(o Money) error {
+	if m.currency != o.currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.currency, o.currency)
+	}
+
+	return nil
+}
+
+// Add returns the sum of both values.
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{m.currency, m.minor + o.minor}, nil
+}
+
+// Sub returns the difference between both values.
+func (m Money) Sub(o Money) (Money, error) {
+	if err := m.check(o); err != nil {
+		return Money{}, err
+	}
+
+	return Money{m.currency, m.minor - o.minor}, nil
+}
+
+// Mul returns the value multiplied by n, e.g. a unit price times a quantity.
+func (m Money) Mul(n int64) Money {
+	return Money{m.currency, m.minor * n}
+}
+
+// Cmp compares both values, returning -1, 0 or +1 when m is lower,
+// equal or greater than o.
+func (m Money) Cmp(o Money) (int, error) {
+	if err := m.check(o); err != nil {
+		return 0, err
+	}
+
+	switch {
+	case m.minor < o.minor:
+		return -1, nil
+	case m.minor > o.minor:
+		return 1, nil
+	default:
+		return 0, nil
+	}
+}
+
+// Allocate splits the value in parts proportional to the given ratios without
+// losing any minor unit: the remainder is distributed one unit at a time,
+// starting with the first part, e.g. EUR 10.00 allocated 1:2 is EUR 3.34
+// and EUR 6.66.
+func (m Money) Allocate(ratios ...int64) ([]Money, error) {
+	var total int64
+
+	for _, r := range ratios {
+		if r < 0 {
+			return nil, fmt.Errorf("%w: negative ratio %d", ErrInvalidMoney, r)
+		}
+
+		total += r
+	}
+
+	if total == 0 {
+		return nil, fmt.Errorf("%w: ratios must not sum to zero", ErrInvalidMoney)
+	}
+
+	parts := make([]Money, len(ratios))
+	remainder := m.minor
+
+	for i, r := range ratios {
+		parts[i] = Money{m.currency, m.minor * r / total}
+		remainder -= parts[i].minor
+	}
+
+	step := int64(1)
+	if remainder < 0 {
+		step = -1
+	}
+
+	for i := 0; remainder != 0; i++ {
+		if ratios[i%len(ratios)] == 0 {
+			continue
+		}
+
+		parts[i%len(ratios)].minor += step
+		remainder -= step
+	}
+
+	return parts, nil
+}
+
+// SetAmount sets the amount to refund.
+func (cpr *CreatePaymentRefund) SetAmount(m Money) {
+	cpr.Amount = m.Amount()
+}
+
+// SetAmount sets the amount to capture.
+func (cc *CreateCapture) SetAmount(m Money) {
+	cc.Amount = m.Amount()
+}
+
+// SetAmount sets the amount routed to the destination.
+func (pr *PaymentRouting) SetAmount(m Money) {
+	pr.Amount = m.Amount()
+}
+
+// SetUnitPrice sets the price of a single unit of the line item.
+func (li *SalesInvoiceLineItem) SetUnitPrice(m Money) {
+	li.UnitPrice = *m.Amount()
+}
+
+// Total returns the unit price times the quantity minus the discount,
+// excluding VAT. Percentage discounts are rounded half up to the minor unit.
+func (li *SalesInvoiceLineItem) Total() (Money, error) {
+	unit, err := li.UnitPrice.Money()
+	if err != nil {
+		return Money{}, err
+	}
+
+	total := unit.Mul(int64(li.Quantity))
+
+	if li.Discount == nil {
+		return total, nil
+	}
+
+	switch li.Discount.Type {
+	case FixedAmountSalesInvoiceDiscountType:
+		discount, err := ParseMoney(unit.currency, li.Discount.Value)
+		if err != nil {
+			return Money{}, err
+		}
+
+		return total.Sub(discount)
+	case PercentageSalesInvoiceDiscountType:
+		// percentages are parsed in hundredths of a percent.
+		pct, err := parseDecimal(li.Discount.Value, 2)
+		if err != nil || pct < 0 || pct > 10000 {
+			return Money{}, fmt.Errorf("%w: discount percentage %q", ErrInvalidMoney, li.Discount.Value)
+		}
+
+		discount := (total.minor*pct + 5000) / 10000
+
+		return Money{total.currency, total.minor - discount}, nil
+	default:
+		return Money{}, fmt.Errorf("%w: unknown discount type %q", ErrInvalidMoney, li.Discount.Type)
+	}
+}
@@ -0,0 +1,165 @@
+package mollie
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMoney(t *testing.T) {
+	cases := []struct {
+		currency string
+		value    string
+		minor    int64
+		str      string
+		wantErr  bool
+	}{
+		{"EUR", "10.00", 1000, "10.00", false},
+		{"EUR", "10.5", 1050, "10.50", false},
+		{"EUR", "10", 1000, "10.00", false},
+		{"EUR", "-0.01", -1, "-0.01", false},
+		{"JPY", "1500", 1500, "1500", false},
+		{"KWD", "1.234", 1234, "1.234", false},
+		{"EUR", "10.001", 0, "", true},
+		{"JPY", "10.5", 0, "", true},
+		{"EUR", "ten", 0, "", true},
+		{"EUR", "", 0, "", true},
+		{"EUR", "--1.00", 0, "", true},
+		{"euro", "1.00", 0, "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.currency+" "+c.value, func(t *testing.T) {
+			m, err := ParseMoney(c.currency, c.value)
+			if c.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMoney)
+
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, c.minor, m.Minor())
+			assert.Equal(t, c.str, m.String())
+			assert.Equal(t, &Amount{Currency: c.currency, Value: c.str}, m.Amount())
+		})
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a, _ := NewMoney("EUR", 1050)
+	b, _ := NewMoney("EUR", 250)
+	usd, _ := NewMoney("USD", 100)
+
+	sum, err := a.Add(b)
+	require.Nil(t, err)
+	assert.Equal(t, "13.00", sum.String())
+
+	diff, err := b.Sub(a)
+	require.Nil(t, err)
+	assert.Equal(t, "-8.00", diff.String())
+	assert.True(t, diff.IsNegative())
+
+	cmp, err := a.Cmp(b)
+	require.Nil(t, err)
+	assert.Equal(t, 1, cmp)
+
+	assert.Equal(t, "31.50", a.Mul(3).String())
+
+	_, err = a.Add(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = a.Cmp(usd)
+	assert.ErrorIs(t, err, ErrCurrencyMismatch)
+
+	_, err = NewMoney("", 1)
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
+
+func TestMoney_Allocate(t *testing.T) {
+	m, _ := NewMoney("EUR", 1000)
+
+	parts, err := m.Allocate(1, 2)
+	require.Nil(t, err)
+	assert.Equal(t, []string{"3.34", "6.66"}, []string{parts[0].String(), parts[1].String()})
+
+	parts, err = m.Allocate(1, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{334, 333, 333}, []int64{parts[0].Minor(), parts[1].Minor(), parts[2].Minor()})
+
+	parts, err = m.Allocate(0, 1, 1, 1)
+	require.Nil(t, err)
+	assert.Equal(t, []int64{0, 334, 333, 333}, []int64{parts[0].Minor(), parts[1].Minor(), parts[2].Minor(), parts[3].Minor()})
+
+	_, err = m.Allocate(0, 0)
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+
+	_, err = m.Allocate(-1, 2)
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
+
+func TestMoney_JSON(t *testing.T) {
+	m, _ := NewMoney("JPY", 1500)
+
+	out, err := json.Marshal(m)
+	require.Nil(t, err)
+	assert.JSONEq(t, `{"currency":"JPY","value":"1500"}`, string(out))
+
+	var back Money
+	require.Nil(t, json.Unmarshal([]byte(`{"currency":"EUR","value":"12.34"}`), &back))
+	assert.Equal(t, int64(1234), back.Minor())
+	assert.NotNil(t, json.Unmarshal([]byte(`{"currency":"EUR","value":"12.345"}`), &back))
+}
+
+func TestMoney_Helpers(t *testing.T) {
+	m, _ := NewMoney("EUR", 500)
+
+	var (
+		refund  CreatePaymentRefund
+		capture CreateCapture
+		routing PaymentRouting
+	)
+
+	refund.SetAmount(m)
+	capture.SetAmount(m)
+	routing.SetAmount(m)
+
+	for _, a := range []*Amount{refund.Amount, capture.Amount, routing.Amount} {
+		assert.Equal(t, &Amount{Currency: "EUR", Value: "5.00"}, a)
+	}
+}
+
+func TestSalesInvoiceLineItem_Total(t *testing.T) {
+	price, _ := NewMoney("EUR", 1999)
+
+	cases := []struct {
+		name     string
+		discount *SalesInvoiceDiscount
+		want     string
+		wantErr  bool
+	}{
+		{"without discount", nil, "59.97", false},
+		{"fixed discount", &SalesInvoiceDiscount{Type: FixedAmountSalesInvoiceDiscountType, Value: "9.97"}, "50.00", false},
+		{"percentage discount", &SalesInvoiceDiscount{Type: PercentageSalesInvoiceDiscountType, Value: "12.5"}, "52.47", false},
+		{"invalid percentage", &SalesInvoiceDiscount{Type: PercentageSalesInvoiceDiscountType, Value: "120"}, "", true},
+		{"unknown type", &SalesInvoiceDiscount{Type: "other", Value: "1"}, "", true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			li := SalesInvoiceLineItem{Quantity: 3, Discount: c.discount}
+			li.SetUnitPrice(price)
+
+			total, err := li.Total()
+			if c.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidMoney)
+
+				return
+			}
+
+			require.Nil(t, err)
+			assert.Equal(t, c.want, total.String())
+		})
+	}
+}
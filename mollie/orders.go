@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -69,10 +70,17 @@ type Order struct {
 	Status                                   OrderStatus   `json:"status,omitempty"`
 	Links                                    OrderLinks    `json:"_links,omitempty"`
 	Metadata                                 any           `json:"metadata,omitempty"`
-	Embedded                                 struct {
-		Payments []*Payment `json:"payments,omitempty"`
-		Refunds  []*Refund  `json:"refunds,omitempty"`
-	} `json:"_embedded,omitempty"`
+	Embedded                                 OrderEmbedded `json:"_embedded,omitempty"`
+}
+
+// OrderEmbedded contains the resources embedded in an order when
+// requested using the embed option.
+//
+// See: https://docs.mollie.com/reference/get-order#embedding-of-related-resources
+type OrderEmbedded struct {
+	Payments  []*Payment  `json:"payments,omitempty"`
+	Refunds   []*Refund   `json:"refunds,omitempty"`
+	Shipments []*Shipment `json:"shipments,omitempty"`
 }
 
 // UpdateOrder contains the parameters to update an order.
@@ -92,7 +100,7 @@ type OrdersList struct {
 	Embedded struct {
 		Orders []*Order `json:"orders,omitempty"`
 	} `json:"_embedded,omitempty"`
-	Links PaginationLinks `json:"links,omitempty"`
+	Links PaginationLinks `json:"_links,omitempty"`
 }
 
 // OrderLinks describes an object with several URL objects
@@ -269,7 +277,7 @@ type OrderRefundsList struct {
 	Embedded struct {
 		Refunds []*Refund `json:"refund,omitempty"`
 	} `json:"_embedded,omitempty"`
-	Links PaginationLinks `json:"links,omitempty"`
+	Links PaginationLinks `json:"_links,omitempty"`
 }
 
 // ProductKind describes the type of product bought, for example, a physical or a digital product.
@@ -303,7 +311,7 @@ const (
 // OrderOptions describes order endpoint valid query string parameters.
 type OrderOptions struct {
 	ProfileID string       `url:"profileId,omitempty"`
-	Embed     []EmbedValue `url:"embed,omitempty"`
+	Embed     []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // ListOrdersOptions describes order endpoint valid query string parameters.
@@ -352,10 +360,6 @@ func (ors *OrdersService) Create(ctx context.Context, ord CreateOrder, opts *Ord
 	order *Order,
 	err error,
 ) {
-	if ors.client.HasAccessToken() && ors.client.config.testing {
-		ord.Testmode = true
-	}
-
 	res, err = ors.client.post(ctx, "v2/orders", ord, opts)
 	if err != nil {
 		return
@@ -376,10 +380,6 @@ func (ors *OrdersService) Update(ctx context.Context, orderID string, ord Update
 	order *Order,
 	err error,
 ) {
-	if ors.client.HasAccessToken() && ors.client.config.testing {
-		ord.Testmode = true
-	}
-
 	res, err = ors.client.patch(ctx, fmt.Sprintf("v2/orders/%s", orderID), ord)
 	if err != nil {
 		return
@@ -428,6 +428,13 @@ func (ors *OrdersService) List(ctx context.Context, opts *ListOrdersOptions) (
 	return
 }
 
+// All returns an iterator over all the orders.
+func (ors *OrdersService) All(ctx context.Context, opts *ListOrdersOptions) iter.Seq2[*Order, error] {
+	return paginate(ctx, ors.client, "v2/orders", opts, func(l *OrdersList) ([]*Order, *URL) {
+		return l.Embedded.Orders, l.Links.Next
+	})
+}
+
 // UpdateOrderLine can be used to update an order line.
 //
 // See https://docs.mollie.com/reference/update-order-line
@@ -442,10 +449,6 @@ func (ors *OrdersService) UpdateOrderLine(
 ) {
 	u := fmt.Sprintf("v2/orders/%s/lines/%s", orderID, orderLineID)
 
-	if ors.client.HasAccessToken() && ors.client.config.testing {
-		orderLine.Testmode = true
-	}
-
 	res, err = ors.client.patch(ctx, u, orderLine)
 	if err != nil {
 		return
@@ -544,6 +547,19 @@ func (ors *OrdersService) ListOrderRefunds(ctx context.Context, orderID string,
 	return
 }
 
+// AllOrderRefunds returns an iterator over all refunds for a specific order.
+func (ors *OrdersService) AllOrderRefunds(
+	ctx context.Context,
+	orderID string,
+	opts *ListOrderRefundsOptions,
+) iter.Seq2[*Refund, error] {
+	u := fmt.Sprintf("v2/orders/%s/refunds", orderID)
+
+	return paginate(ctx, ors.client, u, opts, func(l *OrderRefundsList) ([]*Refund, *URL) {
+		return l.Embedded.Refunds, l.Links.Next
+	})
+}
+
 // ManageOrderLines allows to update, cancel, or add one or more order lines.
 //
 // See: https://docs.mollie.com/reference/manage-order-lines
@@ -8,6 +8,7 @@ import (
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestOrdersService_Get(t *testing.T) {
@@ -1355,3 +1356,29 @@ func TestOrdersService_ManageOrderLines(t *testing.T) {
 		})
 	}
 }
+
+func TestOrdersService_GetEmbedded(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/orders/ord_kEn1PlbGa", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "embed=payments%2Crefunds%2Cshipments")
+		_, _ = w.Write([]byte(testdata.GetOrderEmbeddedResponse))
+	})
+
+	_, o, err := tClient.Orders.Get(context.Background(), "ord_kEn1PlbGa", &OrderOptions{
+		Embed: []EmbedValue{EmbedPayments, EmbedRefunds, EmbedShipments},
+	})
+	require.Nil(t, err)
+	require.Len(t, o.Embedded.Payments, 1)
+	require.Len(t, o.Embedded.Refunds, 1)
+	require.Len(t, o.Embedded.Shipments, 1)
+	assert.Equal(t, "tr_ncaPcAhuUV", o.Embedded.Payments[0].ID)
+	assert.Equal(t, "re_vD3Jm32wQt", o.Embedded.Refunds[0].ID)
+	assert.Equal(t, "shp_3wmsgCJN4U", o.Embedded.Shipments[0].ID)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -143,6 +144,13 @@ func (pls *PaymentLinksService) List(ctx context.Context, opts *PaymentLinkOptio
 	return
 }
 
+// All returns an iterator over all the payment links.
+func (pls *PaymentLinksService) All(ctx context.Context, opts *PaymentLinkOptions) iter.Seq2[*PaymentLink, error] {
+	return paginate(ctx, pls.client, "v2/payment-links", opts, func(l *PaymentLinksList) ([]*PaymentLink, *URL) {
+		return l.Embedded.PaymentLinks, l.Links.Next
+	})
+}
+
 // Update changes certain details of an existing payment link.
 //
 // See: https://docs.mollie.com/reference/update-payment-link
@@ -194,3 +202,16 @@ func (pls *PaymentLinksService) Payments(ctx context.Context, id string, opts *P
 
 	return
 }
+
+// AllPayments returns an iterator over all the payments created for the payment link.
+func (pls *PaymentLinksService) AllPayments(
+	ctx context.Context,
+	id string,
+	opts *PaymentLinkPaymentsListOptions,
+) iter.Seq2[*Payment, error] {
+	u := fmt.Sprintf("v2/payment-links/%s/payments", id)
+
+	return paginate(ctx, pls.client, u, opts, func(l *PaymentLinkPaymentsList) ([]*Payment, *URL) {
+		return l.Embedded.Payments, l.Links.Next
+	})
+}
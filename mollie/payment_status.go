@@ -0,0 +1,165 @@
+package mollie
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// ErrInvalidPaymentTransition is returned when a payment can not move
+// from one status to another.
+var ErrInvalidPaymentTransition = errors.New("invalid payment status transition")
+
+// paymentTransitions lists the statuses a payment can move to, statuses
+// that are not listed are final.
+//
+// See: https://docs.mollie.com/docs/status-change
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusOpen: {
+		PaymentStatusPending,
+		PaymentStatusAuthorized,
+		PaymentStatusPaid,
+		PaymentStatusCanceled,
+		PaymentStatusExpired,
+		PaymentStatusFailed,
+	},
+	PaymentStatusPending: {
+		PaymentStatusAuthorized,
+		PaymentStatusPaid,
+		PaymentStatusCanceled,
+		PaymentStatusExpired,
+		PaymentStatusFailed,
+	},
+	PaymentStatusAuthorized: {
+		PaymentStatusPaid,
+		PaymentStatusCanceled,
+		PaymentStatusExpired,
+	},
+	PaymentStatusPaid:     nil,
+	PaymentStatusCanceled: nil,
+	PaymentStatusExpired:  nil,
+	PaymentStatusFailed:   nil,
+}
+
+// IsValid reports whether the status is one of the documented payment statuses.
+func (ps PaymentStatus) IsValid() bool {
+	_, ok := paymentTransitions[ps]
+
+	return ok
+}
+
+// IsFinal reports whether the status can not change anymore.
+func (ps PaymentStatus) IsFinal() bool {
+	return ps.IsValid() && len(paymentTransitions[ps]) == 0
+}
+
+// Next returns the statuses a payment with this status can move to.
+func (ps PaymentStatus) Next() []PaymentStatus {
+	return slices.Clone(paymentTransitions[ps])
+}
+
+// CanTransitionTo reports whether a payment can move from this status to next.
+func (ps PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	return slices.Contains(paymentTransitions[ps], next)
+}
+
+// ValidatePaymentTransition returns ErrInvalidPaymentTransition when a payment
+// can not move from one status to the other, e.g. from paid back to open.
+func ValidatePaymentTransition(from, to PaymentStatus) error {
+	if !from.CanTransitionTo(to) {
+		return fmt.Errorf("%w: from %q to %q", ErrInvalidPaymentTransition, from, to)
+	}
+
+	return nil
+}
+
+// IsPaid reports whether the payment was paid.
+func (p *Payment) IsPaid() bool {
+	return p.Status == PaymentStatusPaid
+}
+
+// IsFinal reports whether the payment status can not change anymore.
+func (p *Payment) IsFinal() bool {
+	return p.Status.IsFinal()
+}
+
+// CanBeCanceled reports whether the payment can still be canceled.
+func (p *Payment) CanBeCanceled() bool {
+	return p.IsCancelable && !p.IsFinal()
+}
+
+// CanBeRefunded reports whether part of the payment amount can still be refunded.
+func (p *Payment) CanBeRefunded() bool {
+	remaining, err := p.RemainingRefundable()
+
+	return err == nil && remaining.minor > 0
+}
+
+// CanBeCaptured reports whether the payment is authorized and part of its
+// amount was not captured yet.
+func (p *Payment) CanBeCaptured() bool {
+	if p.Status != PaymentStatusAuthorized || p.Amount == nil {
+		return false
+	}
+
+	amount, err := p.Amount.Money()
+	if err != nil {
+		return false
+	}
+
+	if p.AmountCaptured == nil {
+		return amount.minor > 0
+	}
+
+	captured, err := p.AmountCaptured.Money()
+	if err != nil {
+		return false
+	}
+
+	cmp, err := captured.Cmp(amount)
+
+	return err == nil && cmp < 0
+}
+
+// RemainingRefundable returns the amount that can still be refunded, which is
+// zero unless the payment was paid.
+//
+// The amountRemaining returned by Mollie is used when present, otherwise it is
+// derived from the amount minus the refunded and charged back amounts.
+func (p *Payment) RemainingRefundable() (Money, error) {
+	if p.Amount == nil {
+		return Money{}, fmt.Errorf("%w: payment %s has no amount", ErrInvalidMoney, p.ID)
+	}
+
+	amount, err := p.Amount.Money()
+	if err != nil {
+		return Money{}, err
+	}
+
+	if !p.IsPaid() {
+		return Money{currency: amount.currency}, nil
+	}
+
+	if p.AmountRemaining != nil {
+		return p.AmountRemaining.Money()
+	}
+
+	remaining := amount
+
+	for _, a := range []*Amount{p.AmountRefunded, p.AmountChargedBack} {
+		if a == nil {
+			continue
+		}
+
+		m, err := a.Money()
+		if err != nil {
+			return Money{}, err
+		}
+
+		if remaining, err = remaining.Sub(m); err != nil {
+			return Money{}, err
+		}
+	}
+
+	return remaining, nil
+}
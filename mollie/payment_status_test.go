@@ -0,0 +1,89 @@
+package mollie
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaymentStatus_Transitions(t *testing.T) {
+	assert.True(t, PaymentStatusOpen.CanTransitionTo(PaymentStatusPaid))
+	assert.True(t, PaymentStatusAuthorized.CanTransitionTo(PaymentStatusPaid))
+	assert.False(t, PaymentStatusAuthorized.CanTransitionTo(PaymentStatusFailed))
+	assert.False(t, PaymentStatusPaid.CanTransitionTo(PaymentStatusOpen))
+	assert.False(t, PaymentStatus("unknown").CanTransitionTo(PaymentStatusPaid))
+
+	assert.Nil(t, ValidatePaymentTransition(PaymentStatusPending, PaymentStatusExpired))
+	assert.ErrorIs(t, ValidatePaymentTransition(PaymentStatusCanceled, PaymentStatusPaid), ErrInvalidPaymentTransition)
+
+	next := PaymentStatusAuthorized.Next()
+	assert.Equal(t, []PaymentStatus{PaymentStatusPaid, PaymentStatusCanceled, PaymentStatusExpired}, next)
+
+	next[0] = PaymentStatusOpen
+	assert.Equal(t, PaymentStatusPaid, PaymentStatusAuthorized.Next()[0])
+
+	for _, s := range []PaymentStatus{PaymentStatusPaid, PaymentStatusCanceled, PaymentStatusExpired, PaymentStatusFailed} {
+		assert.True(t, s.IsFinal(), s)
+	}
+
+	assert.False(t, PaymentStatusOpen.IsFinal())
+	assert.False(t, PaymentStatus("unknown").IsFinal())
+	assert.False(t, PaymentStatus("unknown").IsValid())
+}
+
+func TestPayment_StatusHelpers(t *testing.T) {
+	var p Payment
+	require.Nil(t, json.Unmarshal([]byte(`{
+		"id": "tr_7UhSN1zuXS",
+		"status": "paid",
+		"isCancelable": false,
+		"amount": {"currency": "EUR", "value": "10.00"},
+		"amountRefunded": {"currency": "EUR", "value": "2.50"},
+		"amountRemaining": {"currency": "EUR", "value": "7.50"}
+	}`), &p))
+
+	assert.Equal(t, PaymentStatusPaid, p.Status)
+	assert.True(t, p.IsPaid())
+	assert.True(t, p.IsFinal())
+	assert.True(t, p.CanBeRefunded())
+	assert.False(t, p.CanBeCaptured())
+	assert.False(t, p.CanBeCanceled())
+
+	remaining, err := p.RemainingRefundable()
+	require.Nil(t, err)
+	assert.Equal(t, "7.50", remaining.String())
+
+	p.AmountRemaining = nil
+	p.AmountChargedBack = &Amount{Currency: "EUR", Value: "7.50"}
+	remaining, err = p.RemainingRefundable()
+	require.Nil(t, err)
+	assert.True(t, remaining.IsZero())
+	assert.False(t, p.CanBeRefunded())
+}
+
+func TestPayment_CanBeCaptured(t *testing.T) {
+	p := Payment{
+		Status:       PaymentStatusAuthorized,
+		IsCancelable: true,
+		Amount:       &Amount{Currency: "EUR", Value: "10.00"},
+	}
+
+	assert.True(t, p.CanBeCaptured())
+	assert.True(t, p.CanBeCanceled())
+	assert.False(t, p.CanBeRefunded())
+
+	p.AmountCaptured = &Amount{Currency: "EUR", Value: "4.00"}
+	assert.True(t, p.CanBeCaptured())
+
+	p.AmountCaptured = &Amount{Currency: "EUR", Value: "10.00"}
+	assert.False(t, p.CanBeCaptured())
+
+	p.Status = PaymentStatusOpen
+	p.AmountCaptured = nil
+	assert.False(t, p.CanBeCaptured())
+
+	_, err := (&Payment{}).RemainingRefundable()
+	assert.ErrorIs(t, err, ErrInvalidMoney)
+}
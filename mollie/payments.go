@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -224,38 +225,55 @@ type UpdatePayment struct {
 	Issuer       string     `json:"issuer,omitempty"`
 }
 
+// PaymentStatus describes the status of a payment.
+type PaymentStatus string
+
+// Valid payment statuses.
+//
+// See: https://docs.mollie.com/docs/status-change
+const (
+	PaymentStatusOpen       PaymentStatus = "open"
+	PaymentStatusPending    PaymentStatus = "pending"
+	PaymentStatusAuthorized PaymentStatus = "authorized"
+	PaymentStatusPaid       PaymentStatus = "paid"
+	PaymentStatusCanceled   PaymentStatus = "canceled"
+	PaymentStatusExpired    PaymentStatus = "expired"
+	PaymentStatusFailed     PaymentStatus = "failed"
+)
+
 // Payment describes a transaction between a customer and a merchant.
 type Payment struct {
-	Resource                        string        `json:"resource,omitempty"`
-	ID                              string        `json:"id,omitempty"`
-	Status                          string        `json:"status,omitempty"`
-	Description                     string        `json:"description,omitempty"`
-	CancelURL                       string        `json:"cancelUrl,omitempty"`
-	WebhookURL                      string        `json:"webhookUrl,omitempty"`
-	CountryCode                     string        `json:"countryCode,omitempty"`
-	RestrictPaymentMethodsToCountry string        `json:"restrictPaymentMethodsToCountry,omitempty"`
-	ProfileID                       string        `json:"profileId,omitempty"`
-	SettlementID                    string        `json:"settlementId,omitempty"`
-	OrderID                         string        `json:"orderId,omitempty"`
-	IsCancelable                    bool          `json:"isCancelable,omitempty"`
-	Mode                            Mode          `json:"mode,omitempty"`
-	Locale                          Locale        `json:"locale,omitempty"`
-	Method                          PaymentMethod `json:"method,omitempty"`
-	Metadata                        any           `json:"metadata,omitempty"`
-	Links                           PaymentLinks  `json:"_links,omitempty"`
-	CreatedAt                       *time.Time    `json:"createdAt,omitempty"`
-	AuthorizedAt                    *time.Time    `json:"authorizedAt,omitempty"`
-	PaidAt                          *time.Time    `json:"paidAt,omitempty"`
-	CanceledAt                      *time.Time    `json:"canceledAt,omitempty"`
-	ExpiresAt                       *time.Time    `json:"expiresAt,omitempty"`
-	ExpiredAt                       *time.Time    `json:"expiredAt,omitempty"`
-	FailedAt                        *time.Time    `json:"failedAt,omitempty"`
-	Amount                          *Amount       `json:"amount,omitempty"`
-	AmountRefunded                  *Amount       `json:"amountRefunded,omitempty"`
-	AmountRemaining                 *Amount       `json:"amountRemaining,omitempty"`
-	AmountCaptured                  *Amount       `json:"amountCaptured,omitempty"`
-	AmountChargedBack               *Amount       `json:"amountChargedback,omitempty"`
-	SettlementAmount                *Amount       `json:"settlementAmount,omitempty"`
+	Resource                        string          `json:"resource,omitempty"`
+	ID                              string          `json:"id,omitempty"`
+	Status                          PaymentStatus   `json:"status,omitempty"`
+	Description                     string          `json:"description,omitempty"`
+	CancelURL                       string          `json:"cancelUrl,omitempty"`
+	WebhookURL                      string          `json:"webhookUrl,omitempty"`
+	CountryCode                     string          `json:"countryCode,omitempty"`
+	RestrictPaymentMethodsToCountry string          `json:"restrictPaymentMethodsToCountry,omitempty"`
+	ProfileID                       string          `json:"profileId,omitempty"`
+	SettlementID                    string          `json:"settlementId,omitempty"`
+	OrderID                         string          `json:"orderId,omitempty"`
+	IsCancelable                    bool            `json:"isCancelable,omitempty"`
+	Mode                            Mode            `json:"mode,omitempty"`
+	Locale                          Locale          `json:"locale,omitempty"`
+	Method                          PaymentMethod   `json:"method,omitempty"`
+	Metadata                        any             `json:"metadata,omitempty"`
+	Links                           PaymentLinks    `json:"_links,omitempty"`
+	Embedded                        PaymentEmbedded `json:"_embedded,omitempty"`
+	CreatedAt                       *time.Time      `json:"createdAt,omitempty"`
+	AuthorizedAt                    *time.Time      `json:"authorizedAt,omitempty"`
+	PaidAt                          *time.Time      `json:"paidAt,omitempty"`
+	CanceledAt                      *time.Time      `json:"canceledAt,omitempty"`
+	ExpiresAt                       *time.Time      `json:"expiresAt,omitempty"`
+	ExpiredAt                       *time.Time      `json:"expiredAt,omitempty"`
+	FailedAt                        *time.Time      `json:"failedAt,omitempty"`
+	Amount                          *Amount         `json:"amount,omitempty"`
+	AmountRefunded                  *Amount         `json:"amountRefunded,omitempty"`
+	AmountRemaining                 *Amount         `json:"amountRemaining,omitempty"`
+	AmountCaptured                  *Amount         `json:"amountCaptured,omitempty"`
+	AmountChargedBack               *Amount         `json:"amountChargedback,omitempty"`
+	SettlementAmount                *Amount         `json:"settlementAmount,omitempty"`
 
 	// Beta fields
 	Lines []PaymentLines `json:"lines,omitempty"`
@@ -270,6 +288,16 @@ type Payment struct {
 	AccessTokenPaymentFields
 }
 
+// PaymentEmbedded contains the resources embedded in a payment when
+// requested using the embed option.
+//
+// See: https://docs.mollie.com/reference/get-payment#embedding-of-related-resources
+type PaymentEmbedded struct {
+	Refunds     []*Refund     `json:"refunds,omitempty"`
+	Chargebacks []*Chargeback `json:"chargebacks,omitempty"`
+	Captures    []*Capture    `json:"captures,omitempty"`
+}
+
 // RecurrentPaymentFields describes the fields specific to recurrent payments.
 type RecurrentPaymentFields struct {
 	SequenceType   SequenceType `json:"sequenceType,omitempty"`
@@ -320,14 +348,14 @@ type PaymentLinks struct {
 // See: https://docs.mollie.com/reference/get-payment
 type PaymentOptions struct {
 	Include []IncludeValue `url:"include,omitempty"`
-	Embed   []EmbedValue   `url:"embed,omitempty"`
+	Embed   []EmbedValue   `url:"embed,omitempty,comma"`
 }
 
 // ListPaymentsOptions describes list payments endpoint valid query string parameters.
 type ListPaymentsOptions struct {
 	Limit     int            `url:"limit,omitempty"`
 	Include   []IncludeValue `url:"include,omitempty"`
-	Embed     []EmbedValue   `url:"embed,omitempty"`
+	Embed     []EmbedValue   `url:"embed,omitempty,comma"`
 	ProfileID string         `url:"profileId,omitempty"`
 	From      string         `url:"from,omitempty"`
 }
@@ -361,10 +389,6 @@ func (ps *PaymentsService) Create(ctx context.Context, p CreatePayment, opts *Pa
 	np *Payment,
 	err error,
 ) {
-	if ps.client.HasAccessToken() && ps.client.config.testing {
-		p.Testmode = true
-	}
-
 	res, err = ps.client.post(ctx, "v2/payments", p, opts)
 	if err != nil {
 		return
@@ -441,3 +465,11 @@ func (ps *PaymentsService) List(ctx context.Context, opts *ListPaymentsOptions)
 
 	return
 }
+
+// All returns an iterator over all payments, following the pagination links
+// until the last page is reached.
+func (ps *PaymentsService) All(ctx context.Context, opts *ListPaymentsOptions) iter.Seq2[*Payment, error] {
+	return paginate(ctx, ps.client, "v2/payments", opts, func(l *PaymentList) ([]*Payment, *URL) {
+		return l.Embedded.Payments, l.Links.Next
+	})
+}
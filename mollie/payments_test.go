@@ -8,6 +8,7 @@ import (
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPaymentsService_Get(t *testing.T) {
@@ -261,7 +262,7 @@ func TestPaymentsService_Create(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -384,7 +385,7 @@ func TestPaymentsService_Update(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_example_token")
 				testMethod(t, r, "PATCH")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -541,3 +542,29 @@ func TestPaymentsService_Cancel(t *testing.T) {
 		})
 	}
 }
+
+func TestPaymentsService_GetEmbedded(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/payments/tr_WDqYK6vllg", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "embed=refunds%2Cchargebacks%2Ccaptures")
+		_, _ = w.Write([]byte(testdata.GetPaymentEmbeddedResponse))
+	})
+
+	_, p, err := tClient.Payments.Get(context.Background(), "tr_WDqYK6vllg", &PaymentOptions{
+		Embed: []EmbedValue{EmbedRefunds, EmbedChargebacks, EmbedCaptures},
+	})
+	require.Nil(t, err)
+	require.Len(t, p.Embedded.Refunds, 1)
+	require.Len(t, p.Embedded.Chargebacks, 1)
+	require.Len(t, p.Embedded.Captures, 1)
+	assert.Equal(t, "re_4qqhO89gsT", p.Embedded.Refunds[0].ID)
+	assert.Equal(t, "chb_n9z0tp", p.Embedded.Chargebacks[0].ID)
+	assert.Equal(t, CaptureStatusSucceeded, p.Embedded.Captures[0].Status)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"net/http"
 	"time"
 )
@@ -118,6 +119,13 @@ func (ps *ProfilesService) List(ctx context.Context, opts *ListProfilesOptions)
 	return
 }
 
+// All returns an iterator over all the profiles for the organization.
+func (ps *ProfilesService) All(ctx context.Context, opts *ListProfilesOptions) iter.Seq2[*Profile, error] {
+	return paginate(ctx, ps.client, "v2/profiles", opts, func(l *ProfilesList) ([]*Profile, *URL) {
+		return l.Embedded.Profiles, l.Links.Next
+	})
+}
+
 // Get retrieves the a profile by ID.
 func (ps *ProfilesService) Get(ctx context.Context, id string) (res *Response, p *Profile, err error) {
 	return ps.get(ctx, id)
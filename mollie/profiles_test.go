@@ -327,7 +327,7 @@ func TestProfilesService_Create(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -425,7 +425,7 @@ func TestProfilesService_Update(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "PATCH")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -522,7 +522,7 @@ func TestProfilesService_Delete(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "DELETE")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -606,7 +606,7 @@ func TestProfilesService_EnablePaymentMethod(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -705,7 +705,7 @@ func TestProfilesService_DisablePaymentMethod(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "DELETE")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -792,7 +792,7 @@ func TestProfilesService_EnableGiftCardIssuer(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -891,7 +891,7 @@ func TestProfilesService_DisableGiftCardIssuer(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "DELETE")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -976,7 +976,7 @@ func TestProfilesService_EnableGiftCardIssuerForCurrent(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -1070,7 +1070,7 @@ func TestProfilesService_DisableGiftCardIssuerForCurrent(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_X12b31ggg23")
 				testMethod(t, r, "DELETE")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -0,0 +1,274 @@
+package mollie
+
+import (
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// EndpointGroup identifies a family of endpoints, e.g. payments or balances.
+//
+// The group of a request is the first resource in its path, except for
+// refunds, chargebacks and captures which have their own group even when
+// nested under a payment or an order.
+type EndpointGroup string
+
+// AccessType distinguishes read requests (GET) from write requests
+// (POST, PATCH and DELETE).
+type AccessType string
+
+// Supported access types for rate limit budgets.
+const (
+	AnyAccess   AccessType = "any"
+	ReadAccess  AccessType = "read"
+	WriteAccess AccessType = "write"
+)
+
+// Bounds for the adaptive slowdown applied after receiving 429 responses.
+const (
+	minThrottleFactor = 1.0 / 16
+	throttleRecovery  = 1.1
+)
+
+var ownGroupResources = map[string]bool{
+	"refunds":     true,
+	"chargebacks": true,
+	"captures":    true,
+}
+
+// EndpointGroupOf returns the endpoint group of a request path,
+// e.g. /v2/payments/tr_WDqYK6vllg/refunds belongs to the refunds group.
+func EndpointGroupOf(path string) EndpointGroup {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 0 && len(segments[0]) > 1 && segments[0][0] == 'v' {
+		segments = segments[1:]
+	}
+
+	if len(segments) == 0 {
+		return ""
+	}
+
+	group := segments[0]
+
+	for _, s := range segments[1:] {
+		if ownGroupResources[s] {
+			group = s
+		}
+	}
+
+	return EndpointGroup(group)
+}
+
+func accessTypeOf(method string) AccessType {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return ReadAccess
+	default:
+		return WriteAccess
+	}
+}
+
+// RateLimit describes a token bucket budget: Rate requests per second
+// are allowed with bursts of up to Burst requests.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+type budgetKey struct {
+	group  EndpointGroup
+	access AccessType
+}
+
+// RateLimiter throttles the requests sent by a client using token buckets.
+//
+// A request has to acquire a token from the global budget and from every
+// budget configured for its endpoint group and access type. When Mollie
+// answers with 429 Too Many Requests the budgets involved are slowed down,
+// and progressively restored with every successful request.
+//
+// A single RateLimiter can be shared between multiple clients.
+type RateLimiter struct {
+	mu      sync.RWMutex
+	global  *tokenBucket
+	budgets map[budgetKey]*tokenBucket
+}
+
+// NewRateLimiter returns a RateLimiter using global as the budget shared by all
+// requests, a zero RateLimit means the global budget is unlimited.
+func NewRateLimiter(global RateLimit) *RateLimiter {
+	rl := &RateLimiter{
+		budgets: make(map[budgetKey]*tokenBucket),
+	}
+
+	if global.Rate > 0 {
+		rl.global = newTokenBucket(global)
+	}
+
+	return rl
+}
+
+// SetLimit configures the budget for the given endpoint group and access type,
+// e.g. a dedicated budget for payments writes.
+func (rl *RateLimiter) SetLimit(group EndpointGroup, access AccessType, limit RateLimit) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	key := budgetKey{group, access}
+
+	if limit.Rate <= 0 {
+		delete(rl.budgets, key)
+
+		return
+	}
+
+	rl.budgets[key] = newTokenBucket(limit)
+}
+
+func (rl *RateLimiter) bucketsFor(req *http.Request) []*tokenBucket {
+	group := EndpointGroupOf(req.URL.Path)
+
+	rl.mu.RLock()
+	defer rl.mu.RUnlock()
+
+	var buckets []*tokenBucket
+	if rl.global != nil {
+		buckets = append(buckets, rl.global)
+	}
+
+	for _, key := range []budgetKey{{group, AnyAccess}, {group, accessTypeOf(req.Method)}} {
+		if b, ok := rl.budgets[key]; ok {
+			buckets = append(buckets, b)
+		}
+	}
+
+	return buckets
+}
+
+// Wait blocks until the request is allowed by every budget it belongs to,
+// or until the request context is done.
+//
+// A token is reserved in every budget before waiting for the slowest one,
+// the reservations are returned when the context is done first.
+func (rl *RateLimiter) Wait(req *http.Request) error {
+	buckets := rl.bucketsFor(req)
+
+	var d time.Duration
+	for _, b := range buckets {
+		d = max(d, b.reserve())
+	}
+
+	if d <= 0 {
+		return nil
+	}
+
+	if err := wait(req.Context(), d); err != nil {
+		for _, b := range buckets {
+			b.cancel()
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// Observe adapts the budgets of the request to the response received:
+// a 429 response slows them down, honouring the Retry-After header,
+// while successful responses restore their rate progressively.
+func (rl *RateLimiter) Observe(req *http.Request, res *Response) {
+	if res == nil || res.Response == nil {
+		return
+	}
+
+	throttled := res.StatusCode == http.StatusTooManyRequests
+	pause, _ := retryAfter(res)
+
+	for _, b := range rl.bucketsFor(req) {
+		if throttled {
+			b.throttle(pause)
+		} else {
+			b.recover()
+		}
+	}
+}
+
+// tokenBucket is a token bucket supporting an adaptive rate factor.
+type tokenBucket struct {
+	mu          sync.Mutex
+	rate        float64
+	burst       float64
+	tokens      float64
+	factor      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+func newTokenBucket(l RateLimit) *tokenBucket {
+	burst := math.Max(float64(l.Burst), 1)
+
+	return &tokenBucket{
+		rate:   l.Rate,
+		burst:  burst,
+		tokens: burst,
+		factor: 1,
+		now:    time.Now,
+	}
+}
+
+// reserve takes a token and returns how long the caller has to wait
+// before using it.
+func (b *tokenBucket) reserve() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	rate := b.rate * b.factor
+
+	if !b.last.IsZero() {
+		b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*rate)
+	}
+
+	b.last = now
+	b.tokens--
+
+	var d time.Duration
+	if b.tokens < 0 {
+		d = time.Duration(-b.tokens / rate * float64(time.Second))
+	}
+
+	if pause := b.pausedUntil.Sub(now); pause > d {
+		d = pause
+	}
+
+	return d
+}
+
+// cancel returns a token that was reserved but not used.
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens = math.Min(b.burst, b.tokens+1)
+}
+
+func (b *tokenBucket) throttle(pause time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.factor = math.Max(b.factor/2, minThrottleFactor)
+
+	if pause > 0 {
+		b.pausedUntil = b.now().Add(pause)
+	}
+}
+
+func (b *tokenBucket) recover() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.factor = math.Min(b.factor*throttleRecovery, 1)
+}
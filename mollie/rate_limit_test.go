@@ -0,0 +1,153 @@
+package mollie
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEndpointGroupOf(t *testing.T) {
+	cases := []struct {
+		path string
+		want EndpointGroup
+	}{
+		{"/v2/payments", "payments"},
+		{"/v2/payments/tr_WDqYK6vllg", "payments"},
+		{"/v2/payments/tr_WDqYK6vllg/refunds", "refunds"},
+		{"/v2/orders/ord_kEn1PlbGa/refunds/re_4qqhO89gsT", "refunds"},
+		{"/v2/payments/tr_WDqYK6vllg/captures", "captures"},
+		{"/v2/balances/primary/transactions", "balances"},
+		{"v2/customers/cst_8wmqcHMN4U/payments", "customers"},
+		{"/", ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.path, func(t *testing.T) {
+			assert.Equal(t, c.want, EndpointGroupOf(c.path))
+		})
+	}
+}
+
+func TestTokenBucket_Reserve(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	b := newTokenBucket(RateLimit{Rate: 2, Burst: 2})
+	b.now = func() time.Time { return now }
+
+	assert.Zero(t, b.reserve())
+	assert.Zero(t, b.reserve())
+	assert.Equal(t, 500*time.Millisecond, b.reserve())
+
+	now = now.Add(time.Second)
+	assert.Zero(t, b.reserve())
+
+	b.throttle(0)
+	assert.Equal(t, time.Second, b.reserve())
+
+	b.throttle(10 * time.Second)
+	assert.Equal(t, 10*time.Second, b.reserve())
+
+	for range 20 {
+		b.recover()
+	}
+
+	assert.Equal(t, 1.0, b.factor)
+}
+
+func TestRateLimiter_Budgets(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{})
+	rl.SetLimit("payments", WriteAccess, RateLimit{Rate: 1, Burst: 1})
+
+	post, _ := http.NewRequest(http.MethodPost, "https://api.mollie.com/v2/payments", nil)
+	get, _ := http.NewRequest(http.MethodGet, "https://api.mollie.com/v2/payments", nil)
+	refund, _ := http.NewRequest(http.MethodPost, "https://api.mollie.com/v2/payments/tr_1/refunds", nil)
+
+	assert.Len(t, rl.bucketsFor(post), 1)
+	assert.Empty(t, rl.bucketsFor(get))
+	assert.Empty(t, rl.bucketsFor(refund))
+
+	rl.SetLimit("payments", WriteAccess, RateLimit{})
+	assert.Empty(t, rl.bucketsFor(post))
+}
+
+func TestRateLimiter_WaitRespectsContext(t *testing.T) {
+	rl := NewRateLimiter(RateLimit{Rate: 0.001, Burst: 1})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.mollie.com/v2/payments", nil)
+
+	require.Nil(t, rl.Wait(req))
+	assert.ErrorIs(t, rl.Wait(req), context.DeadlineExceeded)
+	assert.InDelta(t, 0, rl.global.tokens, 0.01)
+}
+
+func TestRateLimiter_WaitReturnsReservations(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rl := NewRateLimiter(RateLimit{Rate: 100, Burst: 2})
+	rl.SetLimit("payments", WriteAccess, RateLimit{Rate: 0.001, Burst: 1})
+
+	buckets := []*tokenBucket{rl.global, rl.budgets[budgetKey{"payments", WriteAccess}]}
+	for _, b := range buckets {
+		b.now = func() time.Time { return now }
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.mollie.com/v2/payments", nil)
+
+	require.Nil(t, rl.Wait(req))
+	assert.ErrorIs(t, rl.Wait(req), context.DeadlineExceeded)
+	assert.InDelta(t, 1, buckets[0].tokens, 0.01)
+	assert.InDelta(t, 0, buckets[1].tokens, 0.01)
+}
+
+func TestClient_Do_RateLimited(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	rl := NewRateLimiter(RateLimit{Rate: 1000, Burst: 10})
+	tConf.SetRateLimiter(rl)
+
+	calls := 0
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req, err := tClient.NewAPIRequest(context.Background(), http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+
+	_, err = tClient.Do(req)
+	assert.ErrorIs(t, err, ErrRateLimited)
+	assert.Equal(t, 0.5, rl.global.factor)
+
+	_, err = tClient.Do(req)
+	assert.Nil(t, err)
+	assert.InDelta(t, 0.55, rl.global.factor, 0.001)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, err = tClient.NewAPIRequest(ctx, http.MethodGet, "v2/payments", nil)
+	require.Nil(t, err)
+
+	rl.global.tokens = 0
+	_, err = tClient.Do(req)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, calls)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -106,7 +107,7 @@ type RefundLinks struct {
 
 // PaymentRefundOptions describes payment refund endpoint valid query string parameters.
 type PaymentRefundOptions struct {
-	Embed []EmbedValue `url:"embed,omitempty"`
+	Embed []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // ListRefundsOptions describes payment and order refunds list endpoint valid query string parameters.
@@ -114,7 +115,7 @@ type ListRefundsOptions struct {
 	Limit     int          `url:"limit,omitempty"`
 	From      string       `url:"from,omitempty"`
 	ProfileID string       `url:"profileId,omitempty"`
-	Embed     []EmbedValue `url:"embed,omitempty"`
+	Embed     []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // RefundsService instance operates over refund resources.
@@ -140,6 +141,14 @@ func (rs *RefundsService) List(ctx context.Context, opts *ListRefundsOptions) (
 	return
 }
 
+// All returns an iterator over all refunds, following the pagination links
+// until the last page is reached.
+func (rs *RefundsService) All(ctx context.Context, opts *ListRefundsOptions) iter.Seq2[*Refund, error] {
+	return paginate(ctx, rs.client, "v2/refunds", opts, func(l *RefundsList) ([]*Refund, *URL) {
+		return l.Embedded.Refunds, l.Links.Next
+	})
+}
+
 // GetPaymentRefund retrieves a specific refund for a specific payment.
 //
 // See: https://docs.mollie.com/reference/get-refund
@@ -192,6 +201,19 @@ func (rs *RefundsService) ListPaymentRefunds(
 	return
 }
 
+// AllPaymentRefunds returns an iterator over all refunds for a specific payment.
+func (rs *RefundsService) AllPaymentRefunds(
+	ctx context.Context,
+	paymentID string,
+	opts *ListRefundsOptions,
+) iter.Seq2[*Refund, error] {
+	u := fmt.Sprintf("v2/payments/%s/refunds", paymentID)
+
+	return paginate(ctx, rs.client, u, opts, func(l *RefundsList) ([]*Refund, *URL) {
+		return l.Embedded.Refunds, l.Links.Next
+	})
+}
+
 // CreatePaymentRefund performs a refund payment request.
 //
 // See https://docs.mollie.com/reference/create-refund
@@ -207,10 +229,6 @@ func (rs *RefundsService) CreatePaymentRefund(
 ) {
 	uri := fmt.Sprintf("v2/payments/%s/refunds", paymentID)
 
-	if rs.client.HasAccessToken() && rs.client.config.testing {
-		re.Testmode = true
-	}
-
 	res, err = rs.client.post(ctx, uri, re, options)
 	if err != nil {
 		return
@@ -246,10 +264,6 @@ func (rs *RefundsService) CreateOrderRefund(
 ) {
 	uri := fmt.Sprintf("v2/orders/%s/refunds", orderID)
 
-	if rs.client.HasAccessToken() && rs.client.config.testing {
-		r.Testmode = true
-	}
-
 	res, err = rs.client.post(ctx, uri, r, nil)
 	if err != nil {
 		return
@@ -287,3 +301,16 @@ func (rs *RefundsService) ListOrderRefunds(
 
 	return
 }
+
+// AllOrderRefunds returns an iterator over all refunds for a specific order.
+func (rs *RefundsService) AllOrderRefunds(
+	ctx context.Context,
+	orderID string,
+	opts *ListRefundsOptions,
+) iter.Seq2[*Refund, error] {
+	u := fmt.Sprintf("v2/orders/%s/refunds", orderID)
+
+	return paginate(ctx, rs.client, u, opts, func(l *RefundsList) ([]*Refund, *URL) {
+		return l.Embedded.Refunds, l.Links.Next
+	})
+}
@@ -0,0 +1,124 @@
+package mollie
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+// profileEndpoints contains, by method, the endpoints accepting a profileId
+// when called with an organization or OAuth access token, relative to the
+// API version.
+var profileEndpoints = map[string][]string{
+	http.MethodGet: {
+		"payments",
+		"payment-links",
+		"orders",
+		"methods",
+		"methods/*",
+		"subscriptions",
+		"refunds",
+		"chargebacks",
+		"terminals",
+	},
+	http.MethodPost: {
+		"payments",
+		"payment-links",
+		"orders",
+	},
+}
+
+// requestDefaults contains parameters added to every request of a client.
+type requestDefaults struct {
+	profileID string
+	testmode  bool
+}
+
+func (d requestDefaults) isZero() bool {
+	return d.profileID == "" && !d.testmode
+}
+
+// apply adds the defaults to the query string of reads and to the body of
+// writes, parameters already present in the request are kept.
+//
+// The profileId is only added to the profileEndpoints, the body is returned
+// unchanged when no parameter has to be added to it.
+func (d requestDefaults) apply(method string, u *url.URL, body any) (any, error) {
+	if d.isZero() {
+		return body, nil
+	}
+
+	params := make(map[string]any, 2)
+	if d.profileID != "" && acceptsProfileID(method, u.Path) {
+		params["profileId"] = d.profileID
+	}
+
+	if d.testmode {
+		params["testmode"] = true
+	}
+
+	if len(params) == 0 {
+		return body, nil
+	}
+
+	if method == http.MethodGet {
+		qp := u.Query()
+
+		for k, v := range params {
+			if !qp.Has(k) {
+				qp.Set(k, fmt.Sprint(v))
+			}
+		}
+
+		u.RawQuery = qp.Encode()
+
+		return body, nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("encoding_error: %w", err)
+		}
+
+		// only JSON objects can carry the parameters.
+		if json.Unmarshal(b, &fields) != nil {
+			return body, nil
+		}
+
+		// a nil pointer is encoded as null, which is decoded as a nil map.
+		if fields == nil {
+			fields = make(map[string]json.RawMessage)
+		}
+	}
+
+	for k, v := range params {
+		if _, ok := fields[k]; !ok {
+			fields[k], _ = json.Marshal(v)
+		}
+	}
+
+	return fields, nil
+}
+
+// acceptsProfileID reports if the endpoint at p, including the API version,
+// is one of the profileEndpoints of method.
+func acceptsProfileID(method, p string) bool {
+	_, endpoint, ok := strings.Cut(strings.Trim(p, "/"), "/")
+	if !ok {
+		return false
+	}
+
+	for _, pattern := range profileEndpoints[method] {
+		if matched, _ := path.Match(pattern, endpoint); matched {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,93 @@
+package mollie
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRequestDefaults_ProfileID(t *testing.T) {
+	d := requestDefaults{profileID: "pfl_3RkSN1zuPE"}
+
+	cases := []struct {
+		method, path string
+		want         bool
+	}{
+		{http.MethodGet, "/v2/payments", true},
+		{http.MethodPost, "/v2/payments", true},
+		{http.MethodPost, "/v2/payment-links", true},
+		{http.MethodPost, "/v2/orders", true},
+		{http.MethodGet, "/v2/methods", true},
+		{http.MethodGet, "/v2/methods/all", true},
+		{http.MethodGet, "/v2/methods/ideal", true},
+		{http.MethodGet, "/v2/subscriptions", true},
+		{http.MethodGet, "/v2/refunds", true},
+		{http.MethodGet, "/v2/terminals", true},
+		{http.MethodGet, "/v2/payments/tr_WDqYK6vllg", false},
+		{http.MethodGet, "/v2/payments/tr_WDqYK6vllg/refunds", false},
+		{http.MethodPost, "/v2/payments/tr_WDqYK6vllg/refunds", false},
+		{http.MethodPost, "/v2/payments/tr_WDqYK6vllg/captures", false},
+		{http.MethodPost, "/v2/customers", false},
+		{http.MethodPost, "/v2/customers/cst_8wmqcHMN4U/mandates", false},
+		{http.MethodPost, "/v2/profiles", false},
+		{http.MethodPost, "/v2/client-links", false},
+		{http.MethodPost, "/v2/profiles/pfl_v9hTwCvYqw/methods/ideal", false},
+		{http.MethodGet, "/v2/organizations/me", false},
+		{http.MethodPatch, "/v2/payments/tr_WDqYK6vllg", false},
+		{http.MethodDelete, "/v2/payments/tr_WDqYK6vllg", false},
+	}
+
+	for _, c := range cases {
+		t.Run(c.method+" "+c.path, func(t *testing.T) {
+			u, _ := url.Parse("https://api.mollie.com" + c.path)
+
+			body, err := d.apply(c.method, u, nil)
+			require.Nil(t, err)
+
+			if c.method == http.MethodGet {
+				assert.Equal(t, c.want, u.Query().Has("profileId"))
+				assert.Nil(t, body)
+
+				return
+			}
+
+			if c.want {
+				assert.Contains(t, body, "profileId")
+			} else {
+				assert.Nil(t, body)
+			}
+		})
+	}
+}
+
+func TestRequestDefaults_KeepsBodies(t *testing.T) {
+	u, _ := url.Parse("https://api.mollie.com/v2/payments/tr_WDqYK6vllg")
+	patch := UpdatePayment{Description: "Order #12345"}
+
+	body, err := requestDefaults{profileID: "pfl_3RkSN1zuPE"}.apply(http.MethodPatch, u, patch)
+	require.Nil(t, err)
+	assert.Equal(t, patch, body)
+
+	body, err = requestDefaults{testmode: true}.apply(http.MethodDelete, u, nil)
+	require.Nil(t, err)
+	assert.Contains(t, body, "testmode")
+}
+
+func TestRequestDefaults_NilPointerBody(t *testing.T) {
+	u, _ := url.Parse("https://api.mollie.com/v2/payments")
+	d := requestDefaults{profileID: "pfl_3RkSN1zuPE", testmode: true}
+
+	var payment *CreatePayment
+
+	body, err := d.apply(http.MethodPost, u, payment)
+	require.Nil(t, err)
+
+	fields, ok := body.(map[string]json.RawMessage)
+	require.True(t, ok)
+	assert.JSONEq(t, `"pfl_3RkSN1zuPE"`, string(fields["profileId"]))
+	assert.JSONEq(t, `true`, string(fields["testmode"]))
+}
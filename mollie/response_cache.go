@@ -0,0 +1,335 @@
+package mollie
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultCacheSize is the number of responses kept by the LRU store used
+// when no store is passed to NewResponseCache.
+const DefaultCacheSize = 1000
+
+// DefaultCacheTTLs contains the time to live of the endpoint groups cached
+// by a new ResponseCache, these resources rarely change.
+var DefaultCacheTTLs = map[EndpointGroup]time.Duration{
+	"methods":       10 * time.Minute,
+	"profiles":      10 * time.Minute,
+	"organizations": 10 * time.Minute,
+	"permissions":   10 * time.Minute,
+	"terminals":     5 * time.Minute,
+}
+
+// CachedResponse is a successful response stored in a CacheStore.
+type CachedResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+	ExpiresAt  time.Time
+}
+
+// CacheStore stores the responses of a ResponseCache.
+//
+// Implementations must be safe for concurrent use.
+type CacheStore interface {
+	// Get returns the response stored for key, if any.
+	Get(ctx context.Context, key string) (*CachedResponse, bool)
+	// Set stores the response for key.
+	Set(ctx context.Context, key string, res *CachedResponse)
+}
+
+// ResponseCache is a read-through cache for GET requests.
+//
+// Only the endpoint groups with a time to live are cached, concurrent
+// identical requests are collapsed into a single API call, and a successful
+// write to an endpoint group invalidates the cached responses of the group,
+// e.g. updating a profile or enabling one of its payment methods invalidates
+// the cached profiles and payment methods.
+//
+// Responses are cached per authentication token, so a single ResponseCache
+// can be shared between multiple clients.
+//
+// Invalidation is local to the process: the generations of the endpoint
+// groups are kept in memory, so a CacheStore shared between processes keeps
+// serving the responses invalidated by the writes of another process until
+// they expire.
+type ResponseCache struct {
+	store CacheStore
+	now   func() time.Time
+
+	mu          sync.Mutex
+	ttls        map[EndpointGroup]time.Duration
+	invalidates map[EndpointGroup][]EndpointGroup
+	generations map[EndpointGroup]uint64
+	inflight    map[string]*cacheCall
+}
+
+// cacheCall is a request shared by concurrent identical GET requests.
+type cacheCall struct {
+	done chan struct{}
+	res  *Response
+	err  error
+	// canceled reports if the call failed because the context of the
+	// request that performed it was done.
+	canceled bool
+}
+
+// NewResponseCache returns a ResponseCache storing its responses in store,
+// an LRU store of DefaultCacheSize responses is used when store is nil.
+//
+// The cache uses DefaultCacheTTLs, writes to profiles also invalidate the
+// payment methods.
+func NewResponseCache(store CacheStore) *ResponseCache {
+	if store == nil {
+		store = NewLRUCacheStore(DefaultCacheSize)
+	}
+
+	rc := &ResponseCache{
+		store:       store,
+		now:         time.Now,
+		ttls:        make(map[EndpointGroup]time.Duration, len(DefaultCacheTTLs)),
+		invalidates: map[EndpointGroup][]EndpointGroup{"profiles": {"methods"}},
+		generations: make(map[EndpointGroup]uint64),
+		inflight:    make(map[string]*cacheCall),
+	}
+
+	for group, ttl := range DefaultCacheTTLs {
+		rc.ttls[group] = ttl
+	}
+
+	return rc
+}
+
+// SetTTL changes the time to live of the responses of an endpoint group,
+// a zero duration disables caching for the group.
+func (rc *ResponseCache) SetTTL(group EndpointGroup, ttl time.Duration) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	if ttl <= 0 {
+		delete(rc.ttls, group)
+
+		return
+	}
+
+	rc.ttls[group] = ttl
+}
+
+// SetInvalidation declares that a successful write to group also
+// invalidates the cached responses of the related groups.
+func (rc *ResponseCache) SetInvalidation(group EndpointGroup, related ...EndpointGroup) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.invalidates[group] = related
+}
+
+// Invalidate discards the cached responses of the given endpoint groups.
+//
+// The responses are not removed from the store, they are no longer read
+// and eventually evicted.
+func (rc *ResponseCache) Invalidate(groups ...EndpointGroup) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	for _, g := range groups {
+		rc.generations[g]++
+	}
+}
+
+// wrap returns a Doer serving cacheable requests from the cache.
+func (rc *ResponseCache) wrap(next Doer) Doer {
+	return func(req *http.Request) (*Response, error) {
+		group := EndpointGroupOf(req.URL.Path)
+
+		if req.Method != http.MethodGet {
+			res, err := next(req)
+			if err == nil {
+				rc.mu.Lock()
+				related := rc.invalidates[group]
+				rc.mu.Unlock()
+
+				rc.Invalidate(append([]EndpointGroup{group}, related...)...)
+			}
+
+			return res, err
+		}
+
+		rc.mu.Lock()
+		ttl, ok := rc.ttls[group]
+		key := rc.key(req, group)
+		rc.mu.Unlock()
+
+		if !ok {
+			return next(req)
+		}
+
+		if cached, ok := rc.store.Get(req.Context(), key); ok && rc.now().Before(cached.ExpiresAt) {
+			return cached.response(req), nil
+		}
+
+		return rc.fetch(req, key, ttl, next)
+	}
+}
+
+// fetch performs the request, or waits for an identical request in flight.
+//
+// When the request in flight fails because its own context is done, the
+// waiters whose context is still alive perform the request again.
+func (rc *ResponseCache) fetch(req *http.Request, key string, ttl time.Duration, next Doer) (*Response, error) {
+	for {
+		rc.mu.Lock()
+
+		call, ok := rc.inflight[key]
+		if !ok {
+			break
+		}
+
+		rc.mu.Unlock()
+
+		select {
+		case <-call.done:
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+
+		if call.canceled && req.Context().Err() == nil {
+			continue
+		}
+
+		if call.err != nil {
+			return call.res, call.err
+		}
+
+		return cachedResponse(call.res, time.Time{}).response(req), nil
+	}
+
+	call := &cacheCall{done: make(chan struct{})}
+	rc.inflight[key] = call
+	rc.mu.Unlock()
+
+	call.res, call.err = next(req)
+	call.canceled = call.err != nil && req.Context().Err() != nil
+
+	if call.err == nil {
+		rc.store.Set(req.Context(), key, cachedResponse(call.res, rc.now().Add(ttl)))
+	}
+
+	rc.mu.Lock()
+	delete(rc.inflight, key)
+	rc.mu.Unlock()
+	close(call.done)
+
+	return call.res, call.err
+}
+
+// key identifies a request by its url, its credentials and the generation
+// of its endpoint group, rc.mu must be held.
+func (rc *ResponseCache) key(req *http.Request, group EndpointGroup) string {
+	auth := sha256.Sum256([]byte(req.Header.Get(AuthHeader)))
+
+	return string(group) + "#" + strconv.FormatUint(rc.generations[group], 10) + " " +
+		hex.EncodeToString(auth[:8]) + " " + req.URL.String()
+}
+
+func cachedResponse(res *Response, expires time.Time) *CachedResponse {
+	return &CachedResponse{
+		StatusCode: res.StatusCode,
+		Header:     res.Header.Clone(),
+		Body:       res.content,
+		ExpiresAt:  expires,
+	}
+}
+
+// response returns a new Response with the cached status, headers and body.
+func (cr *CachedResponse) response(req *http.Request) *Response {
+	return &Response{
+		Response: &http.Response{
+			Status:        strconv.Itoa(cr.StatusCode) + " " + http.StatusText(cr.StatusCode),
+			StatusCode:    cr.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        cr.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewReader(cr.Body)),
+			ContentLength: int64(len(cr.Body)),
+			Request:       req,
+		},
+		content: cr.Body,
+	}
+}
+
+// LRUCacheStore is an in-memory CacheStore evicting the least recently
+// used responses once full.
+type LRUCacheStore struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List
+	items    map[string]*list.Element
+}
+
+type lruItem struct {
+	key string
+	res *CachedResponse
+}
+
+// NewLRUCacheStore returns an LRUCacheStore keeping up to capacity responses.
+func NewLRUCacheStore(capacity int) *LRUCacheStore {
+	return &LRUCacheStore{
+		capacity: max(capacity, 1),
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+// Get returns the response stored for key and marks it as recently used.
+func (s *LRUCacheStore) Get(_ context.Context, key string) (*CachedResponse, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	el, ok := s.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	s.order.MoveToFront(el)
+
+	return el.Value.(*lruItem).res, true
+}
+
+// Set stores the response for key, evicting the least recently used
+// response when the store is full.
+func (s *LRUCacheStore) Set(_ context.Context, key string, res *CachedResponse) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if el, ok := s.items[key]; ok {
+		el.Value.(*lruItem).res = res
+		s.order.MoveToFront(el)
+
+		return
+	}
+
+	s.items[key] = s.order.PushFront(&lruItem{key: key, res: res})
+
+	if s.order.Len() > s.capacity {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.items, oldest.Value.(*lruItem).key)
+	}
+}
+
+// Len returns the number of responses in the store.
+func (s *LRUCacheStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.order.Len()
+}
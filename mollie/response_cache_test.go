@@ -0,0 +1,270 @@
+package mollie
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cachedAPI registers handlers counting the requests they receive.
+func cachedAPI(t *testing.T) map[string]*atomic.Int32 {
+	t.Helper()
+
+	hits := map[string]*atomic.Int32{}
+	handle := func(pattern, body string, status int) {
+		hits[pattern] = new(atomic.Int32)
+		tMux.HandleFunc(pattern, func(w http.ResponseWriter, _ *http.Request) {
+			hits[pattern].Add(1)
+			w.WriteHeader(status)
+			_, _ = w.Write([]byte(body))
+		})
+	}
+
+	handle("GET /v2/methods", testdata.ListMethodsResponse, http.StatusOK)
+	handle("GET /v2/profiles/me", testdata.GetProfileResponse, http.StatusOK)
+	handle("GET /v2/organizations/me", testdata.GetCurrentOrganizationResponse, http.StatusOK)
+	handle("GET /v2/payments/tr_WDqYK6vllg", testdata.GetPaymentResponse, http.StatusOK)
+	handle("GET /v2/permissions", testdata.ListPermissionsResponse, http.StatusInternalServerError)
+	handle("POST /v2/profiles/pfl_v9hTwCvYqw/methods/bancontact", testdata.EnablePaymentMethodResponse, http.StatusCreated)
+	handle("PATCH /v2/profiles/pfl_v9hTwCvYqw", testdata.UpdateProfileResponse, http.StatusUnprocessableEntity)
+
+	return hits
+}
+
+func TestResponseCache(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	rc := NewResponseCache(nil)
+	tClient.config.SetResponseCache(rc)
+	hits := cachedAPI(t)
+	ctx := context.Background()
+
+	for range 3 {
+		_, _, err := tClient.PaymentMethods.List(ctx, nil)
+		require.Nil(t, err)
+		_, p, err := tClient.Profiles.Current(ctx)
+		require.Nil(t, err)
+		assert.Equal(t, "pfl_v9hTwCvYqw", p.ID)
+		_, _, err = tClient.Payments.Get(ctx, "tr_WDqYK6vllg", nil)
+		require.Nil(t, err)
+		_, _, err = tClient.Permissions.List(ctx)
+		require.NotNil(t, err)
+	}
+
+	assert.EqualValues(t, 1, hits["GET /v2/methods"].Load())
+	assert.EqualValues(t, 1, hits["GET /v2/profiles/me"].Load())
+	assert.EqualValues(t, 3, hits["GET /v2/payments/tr_WDqYK6vllg"].Load(), "uncached groups always hit the API")
+	assert.EqualValues(t, 3, hits["GET /v2/permissions"].Load(), "failures are not cached")
+
+	_, _, err := tClient.PaymentMethods.List(ctx, &ListPaymentMethodsOptions{BillingCountry: "NL"})
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, hits["GET /v2/methods"].Load(), "the query string is part of the key")
+
+	require.Nil(t, tClient.WithAuthenticationValue("test_anotherKey0000000000000000"))
+	_, _, err = tClient.PaymentMethods.List(ctx, nil)
+	require.Nil(t, err)
+	assert.EqualValues(t, 3, hits["GET /v2/methods"].Load(), "responses are cached per token")
+}
+
+func TestResponseCache_Invalidation(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	rc := NewResponseCache(nil)
+	tClient.config.SetResponseCache(rc)
+	hits := cachedAPI(t)
+	ctx := context.Background()
+
+	warm := func() {
+		t.Helper()
+
+		_, _, err := tClient.PaymentMethods.List(ctx, nil)
+		require.Nil(t, err)
+		_, _, err = tClient.Profiles.Current(ctx)
+		require.Nil(t, err)
+		_, _, err = tClient.Organizations.GetCurrent(ctx)
+		require.Nil(t, err)
+	}
+
+	warm()
+	warm()
+
+	_, _, err := tClient.Profiles.Update(ctx, "pfl_v9hTwCvYqw", CreateOrUpdateProfile{Name: "Acme"})
+	require.NotNil(t, err)
+	warm()
+	assert.EqualValues(t, 1, hits["GET /v2/methods"].Load(), "failed writes do not invalidate")
+
+	_, _, err = tClient.Profiles.EnablePaymentMethod(ctx, "pfl_v9hTwCvYqw", Bancontact)
+	require.Nil(t, err)
+	warm()
+	assert.EqualValues(t, 2, hits["GET /v2/methods"].Load())
+	assert.EqualValues(t, 2, hits["GET /v2/profiles/me"].Load())
+	assert.EqualValues(t, 1, hits["GET /v2/organizations/me"].Load())
+
+	rc.Invalidate("organizations")
+	warm()
+	assert.EqualValues(t, 2, hits["GET /v2/organizations/me"].Load())
+}
+
+func TestResponseCache_TTL(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	now := time.Now()
+	rc := NewResponseCache(nil)
+	rc.now = func() time.Time { return now }
+	rc.SetTTL("methods", time.Minute)
+	rc.SetTTL("profiles", 0)
+	tClient.config.SetResponseCache(rc)
+	hits := cachedAPI(t)
+	ctx := context.Background()
+
+	for range 2 {
+		_, _, err := tClient.PaymentMethods.List(ctx, nil)
+		require.Nil(t, err)
+		_, _, err = tClient.Profiles.Current(ctx)
+		require.Nil(t, err)
+	}
+
+	assert.EqualValues(t, 1, hits["GET /v2/methods"].Load())
+	assert.EqualValues(t, 2, hits["GET /v2/profiles/me"].Load())
+
+	now = now.Add(time.Minute)
+	_, _, err := tClient.PaymentMethods.List(ctx, nil)
+	require.Nil(t, err)
+	assert.EqualValues(t, 2, hits["GET /v2/methods"].Load())
+}
+
+func TestResponseCache_CollapsesConcurrentRequests(t *testing.T) {
+	rc := NewResponseCache(nil)
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	next := rc.wrap(func(req *http.Request) (*Response, error) {
+		calls.Add(1)
+		<-release
+
+		return cachedResponse(&Response{
+			Response: &http.Response{StatusCode: http.StatusOK},
+			content:  []byte(testdata.GetCurrentOrganizationResponse),
+		}, time.Time{}).response(req), nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			req, _ := http.NewRequest(http.MethodGet, "https://api.mollie.com/v2/organizations/me", nil)
+			res, err := next(req)
+			assert.Nil(t, err)
+			assert.JSONEq(t, testdata.GetCurrentOrganizationResponse, string(res.content))
+		}()
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.EqualValues(t, 1, calls.Load())
+}
+
+func TestResponseCache_WaitersSurviveLeaderCancellation(t *testing.T) {
+	rc := NewResponseCache(nil)
+
+	var calls atomic.Int32
+
+	started := make(chan struct{})
+	next := rc.wrap(func(req *http.Request) (*Response, error) {
+		if calls.Add(1) == 1 {
+			close(started)
+			<-req.Context().Done()
+
+			return nil, req.Context().Err()
+		}
+
+		return cachedResponse(&Response{
+			Response: &http.Response{StatusCode: http.StatusOK},
+			content:  []byte(testdata.GetCurrentOrganizationResponse),
+		}, time.Time{}).response(req), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.mollie.com/v2/organizations/me", nil)
+	waiter, _ := http.NewRequest(http.MethodGet, "https://api.mollie.com/v2/organizations/me", nil)
+
+	leaderErr := make(chan error, 1)
+
+	go func() {
+		_, err := next(leader)
+		leaderErr <- err
+	}()
+
+	<-started
+
+	type result struct {
+		res *Response
+		err error
+	}
+
+	waited := make(chan result, 1)
+
+	go func() {
+		res, err := next(waiter)
+		waited <- result{res, err}
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+
+	assert.ErrorIs(t, <-leaderErr, context.Canceled)
+
+	r := <-waited
+	require.Nil(t, r.err)
+	assert.JSONEq(t, testdata.GetCurrentOrganizationResponse, string(r.res.content))
+	assert.EqualValues(t, 2, calls.Load())
+}
+
+func TestLRUCacheStore(t *testing.T) {
+	ctx := context.Background()
+	s := NewLRUCacheStore(2)
+
+	s.Set(ctx, "a", &CachedResponse{Body: []byte("a")})
+	s.Set(ctx, "b", &CachedResponse{Body: []byte("b")})
+
+	_, ok := s.Get(ctx, "a")
+	require.True(t, ok)
+
+	s.Set(ctx, "c", &CachedResponse{Body: []byte("c")})
+
+	_, ok = s.Get(ctx, "b")
+	assert.False(t, ok, "the least recently used response is evicted")
+
+	a, ok := s.Get(ctx, "a")
+	require.True(t, ok)
+	assert.Equal(t, "a", string(a.Body))
+	assert.Equal(t, 2, s.Len())
+}
@@ -0,0 +1,183 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values used by the BackoffPolicy.
+const (
+	DefaultRetryAttempts  = 3
+	DefaultRetryBaseDelay = 500 * time.Millisecond
+	DefaultRetryMaxDelay  = 30 * time.Second
+)
+
+// RetryPolicy decides if a failed request should be attempted again
+// and how long the client has to wait before doing so.
+//
+// The client only consults the policy for requests that are safe to repeat:
+// GET requests are always safe, POST, PATCH and DELETE requests are only
+// safe when an Idempotency-Key header was sent with the original request.
+// The client generates one for them when idempotency is enabled in the
+// Config.
+type RetryPolicy interface {
+	// Retry receives the number of attempts performed so far, the response
+	// (nil on transport errors) and the error returned by the attempt.
+	//
+	// It returns the delay before the next attempt and true when the request
+	// must be retried.
+	Retry(attempt int, res *Response, err error) (time.Duration, bool)
+}
+
+// BackoffPolicy is a RetryPolicy using exponential backoff with full jitter.
+//
+// Transport errors, 429 Too Many Requests and 500, 502, 503 and 504 responses
+// are retried. When Mollie returns a Retry-After header its value takes
+// precedence over the computed backoff.
+type BackoffPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the delay used for the first retry, doubled on every attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the delay between two attempts.
+	MaxDelay time.Duration
+}
+
+// NewBackoffPolicy returns a BackoffPolicy performing at most maxAttempts
+// attempts and using the default delays.
+//
+// If maxAttempts is lower than 1, DefaultRetryAttempts is used.
+func NewBackoffPolicy(maxAttempts int) *BackoffPolicy {
+	if maxAttempts < 1 {
+		maxAttempts = DefaultRetryAttempts
+	}
+
+	return &BackoffPolicy{
+		MaxAttempts: maxAttempts,
+		BaseDelay:   DefaultRetryBaseDelay,
+		MaxDelay:    DefaultRetryMaxDelay,
+	}
+}
+
+// Retry implements the RetryPolicy interface.
+func (bp *BackoffPolicy) Retry(attempt int, res *Response, err error) (time.Duration, bool) {
+	if attempt >= bp.MaxAttempts || !retryableFailure(res, err) {
+		return 0, false
+	}
+
+	if d, ok := retryAfter(res); ok {
+		return min(d, bp.MaxDelay), true
+	}
+
+	return bp.backoff(attempt), true
+}
+
+func (bp *BackoffPolicy) backoff(attempt int) time.Duration {
+	d := bp.BaseDelay << (attempt - 1)
+	if d <= 0 || d > bp.MaxDelay {
+		d = bp.MaxDelay
+	}
+
+	if d <= 0 {
+		return 0
+	}
+
+	return rand.N(d) //#nosec G404 -- jitter does not require a secure source.
+}
+
+func retryableFailure(res *Response, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if res == nil || res.Response == nil {
+		return err != nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// retryAfter parses the Retry-After header, which can be expressed
+// either in seconds or as an HTTP date.
+func retryAfter(res *Response) (time.Duration, bool) {
+	if res == nil || res.Response == nil {
+		return 0, false
+	}
+
+	v := res.Header.Get("Retry-After")
+	if v == "" {
+		return 0, false
+	}
+
+	if secs, err := strconv.Atoi(v); err == nil && secs >= 0 {
+		return time.Duration(secs) * time.Second, true
+	}
+
+	if at, err := http.ParseTime(v); err == nil {
+		return max(time.Until(at), 0), true
+	}
+
+	return 0, false
+}
+
+// canRetry reports if the request can be safely sent more than once.
+func canRetry(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch {
+	case req.Method == http.MethodGet, req.Method == http.MethodHead:
+		return true
+	case acceptsIdempotencyKey(req.Method):
+		return req.Header.Get(IdempotencyKeyHeader) != ""
+	default:
+		return false
+	}
+}
+
+// rewind prepares the request to be sent again.
+func rewind(req *http.Request) error {
+	if req.GetBody == nil {
+		return nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+
+	req.Body = body
+
+	return nil
+}
+
+// wait blocks for the given delay or until the context is done.
+func wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
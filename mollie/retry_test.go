@@ -0,0 +1,256 @@
+package mollie
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/pkg/idempotency"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackoffPolicy_Retry(t *testing.T) {
+	newRes := func(code int, retryAfter string) *Response {
+		h := http.Header{}
+		if retryAfter != "" {
+			h.Set("Retry-After", retryAfter)
+		}
+
+		return &Response{Response: &http.Response{StatusCode: code, Header: h}}
+	}
+
+	cases := []struct {
+		name    string
+		attempt int
+		res     *Response
+		err     error
+		retry   bool
+		delay   time.Duration
+	}{
+		{"service unavailable is retried", 1, newRes(http.StatusServiceUnavailable, ""), fmt.Errorf("503"), true, 0},
+		{"too many requests is retried", 1, newRes(http.StatusTooManyRequests, ""), fmt.Errorf("429"), true, 0},
+		{"transport errors are retried", 1, nil, fmt.Errorf("http_error: EOF"), true, 0},
+		{"unprocessable entity is not retried", 1, newRes(http.StatusUnprocessableEntity, ""), fmt.Errorf("422"), false, 0},
+		{"not found is not retried", 1, newRes(http.StatusNotFound, ""), fmt.Errorf("404"), false, 0},
+		{"context cancellation is not retried", 1, nil, context.Canceled, false, 0},
+		{"max attempts is respected", 3, newRes(http.StatusServiceUnavailable, ""), fmt.Errorf("503"), false, 0},
+		{"retry after in seconds is honoured", 1, newRes(http.StatusTooManyRequests, "2"), fmt.Errorf("429"), true, 2 * time.Second},
+		{"retry after is capped", 1, newRes(http.StatusTooManyRequests, "3600"), fmt.Errorf("429"), true, DefaultRetryMaxDelay},
+	}
+
+	p := NewBackoffPolicy(3)
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			d, ok := p.Retry(c.attempt, c.res, c.err)
+			assert.Equal(t, c.retry, ok)
+
+			if c.delay > 0 {
+				assert.Equal(t, c.delay, d)
+			} else {
+				assert.LessOrEqual(t, d, DefaultRetryMaxDelay)
+			}
+		})
+	}
+}
+
+func TestNewBackoffPolicy_Defaults(t *testing.T) {
+	p := NewBackoffPolicy(0)
+
+	assert.Equal(t, DefaultRetryAttempts, p.MaxAttempts)
+	assert.Equal(t, DefaultRetryBaseDelay, p.BaseDelay)
+	assert.Equal(t, DefaultRetryMaxDelay, p.MaxDelay)
+}
+
+func TestClient_Do_Retries(t *testing.T) {
+	cases := []struct {
+		name     string
+		method   string
+		key      bool
+		attempts int
+		wantErr  bool
+	}{
+		{"get requests are retried until success", http.MethodGet, false, 3, false},
+		{"post requests with idempotency key are retried", http.MethodPost, true, 3, false},
+		{"post requests without idempotency key are not retried", http.MethodPost, false, 1, true},
+		{"patch requests with idempotency key are retried", http.MethodPatch, true, 3, false},
+		{"patch requests without idempotency key are not retried", http.MethodPatch, false, 1, true},
+		{"delete requests with idempotency key are retried", http.MethodDelete, true, 3, false},
+		{"delete requests without idempotency key are not retried", http.MethodDelete, false, 1, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setEnv()
+			setup()
+			defer teardown()
+			defer unsetEnv()
+
+			if !c.key {
+				tConf.ToggleIdempotency()
+			}
+
+			tConf.SetRetryPolicy(&BackoffPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond})
+
+			var (
+				calls int
+				keys  []string
+			)
+
+			tMux.HandleFunc("/v2/retry", func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				keys = append(keys, r.Header.Get(IdempotencyKeyHeader))
+
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+					assert.Equal(t, "{\"id\":\"tr_test\"}\n", string(body))
+				}
+
+				if calls < 3 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusOK)
+			})
+
+			var body any
+			if c.method == http.MethodPost || c.method == http.MethodPatch {
+				body = map[string]string{"id": "tr_test"}
+			}
+
+			req, err := tClient.NewAPIRequest(context.Background(), c.method, "v2/retry", body)
+			require.Nil(t, err)
+
+			_, err = tClient.Do(req)
+			if c.wantErr {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+
+			assert.Equal(t, c.attempts, calls)
+
+			for _, k := range keys {
+				assert.Equal(t, keys[0], k)
+			}
+
+			if c.key {
+				assert.NotEmpty(t, keys[0])
+			}
+		})
+	}
+}
+
+func TestClient_Do_RetryStopsOnContextCancellation(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	tConf.SetRetryPolicy(&BackoffPolicy{MaxAttempts: 5, BaseDelay: time.Hour, MaxDelay: time.Hour})
+
+	calls := 0
+	tMux.HandleFunc("/v2/retry", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	req, err := tClient.NewAPIRequest(ctx, http.MethodGet, "v2/retry", nil)
+	require.Nil(t, err)
+
+	_, err = tClient.Do(req)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestWithIdempotencyKey(t *testing.T) {
+	cases := []struct {
+		name   string
+		method string
+		want   string
+	}{
+		{"post requests use the context key", http.MethodPost, "my-stable-key"},
+		{"patch requests use the context key", http.MethodPatch, "my-stable-key"},
+		{"delete requests use the context key", http.MethodDelete, "my-stable-key"},
+		{"get requests ignore the context key", http.MethodGet, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setEnv()
+			setup()
+			defer teardown()
+			defer unsetEnv()
+
+			tClient.SetIdempotencyKeyGenerator(idempotency.NewNopGenerator("generated"))
+
+			ctx := WithIdempotencyKey(context.Background(), "my-stable-key")
+
+			req, err := tClient.NewAPIRequest(ctx, c.method, "v2/payments", nil)
+			require.Nil(t, err)
+			testHeader(t, req, IdempotencyKeyHeader, c.want)
+		})
+	}
+}
+
+func TestRetryAfter_HTTPDate(t *testing.T) {
+	h := http.Header{}
+	h.Set("Retry-After", time.Now().Add(-time.Minute).UTC().Format(http.TimeFormat))
+
+	d, ok := retryAfter(&Response{Response: &http.Response{Header: h}})
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	h.Set("Retry-After", "soon")
+
+	_, ok = retryAfter(&Response{Response: &http.Response{Header: h}})
+	assert.False(t, ok)
+}
+
+func TestCanRetry(t *testing.T) {
+	cases := []struct {
+		method string
+		key    bool
+		want   bool
+	}{
+		{http.MethodGet, false, true},
+		{http.MethodHead, false, true},
+		{http.MethodPost, false, false},
+		{http.MethodPost, true, true},
+		{http.MethodPatch, false, false},
+		{http.MethodPatch, true, true},
+		{http.MethodDelete, false, false},
+		{http.MethodDelete, true, true},
+		{http.MethodPut, true, false},
+	}
+
+	for _, c := range cases {
+		t.Run(fmt.Sprintf("%s with key %t", c.method, c.key), func(t *testing.T) {
+			req, err := http.NewRequest(c.method, "https://api.mollie.com/v2/payments/tr_1", nil)
+			require.Nil(t, err)
+
+			if c.key {
+				req.Header.Set(IdempotencyKeyHeader, "key")
+			}
+
+			assert.Equal(t, c.want, canRetry(req))
+		})
+	}
+}
+
+func TestCanRetry_UnreplayableBody(t *testing.T) {
+	req, err := http.NewRequest(http.MethodGet, "https://api.mollie.com/v2/payments", io.NopCloser(strings.NewReader("x")))
+	require.Nil(t, err)
+
+	assert.False(t, canRetry(req))
+}
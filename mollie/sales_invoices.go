@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -243,6 +244,16 @@ func (s *SalesInvoicesService) List(ctx context.Context, opts *ListSalesInvoices
 	return
 }
 
+// All returns an iterator over all the sales invoices.
+func (s *SalesInvoicesService) All(
+	ctx context.Context,
+	opts *ListSalesInvoicesOptions,
+) iter.Seq2[*SalesInvoice, error] {
+	return paginate(ctx, s.client, "v2/sales-invoices", opts, func(l *SalesInvoiceList) ([]*SalesInvoice, *URL) {
+		return pointers(l.Embedded.SalesInvoices), l.Links.Next
+	})
+}
+
 // Get retrieves a single sales invoice by its ID.
 //
 // See: https://docs.mollie.com/reference/get-sales-invoice
@@ -273,10 +284,6 @@ func (s *SalesInvoicesService) Create(ctx context.Context, csi CreateSalesInvoic
 	si *SalesInvoice,
 	err error,
 ) {
-	if s.client.HasAccessToken() && s.client.config.testing {
-		csi.TestMode = true
-	}
-
 	res, err = s.client.post(ctx, "/v2/sales-invoices", csi, nil)
 	if err != nil {
 		return
@@ -299,10 +306,6 @@ func (s *SalesInvoicesService) Update(ctx context.Context, salesInvoice string,
 ) {
 	u := fmt.Sprintf("/v2/sales-invoices/%s", salesInvoice)
 
-	if s.client.HasAccessToken() && s.client.config.testing {
-		usi.TestMode = true
-	}
-
 	res, err = s.client.patch(ctx, u, usi)
 	if err != nil {
 		return
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -65,23 +66,40 @@ type SettlementLinks struct {
 // Settlement contains successful payments, together with refunds,
 // captures and chargebacks into settlements.
 type Settlement struct {
-	ID        string            `json:"id,omitempty"`
-	Resource  string            `json:"resource,omitempty"`
-	Reference string            `json:"reference,omitempty"`
-	InvoiceID string            `json:"invoiceId,omitempty"`
-	CreatedAt *time.Time        `json:"createdAt,omitempty"`
-	SettledAt *time.Time        `json:"settledAt,omitempty"`
-	Amount    *Amount           `json:"amount,omitempty"`
-	Periods   *SettlementObject `json:"periods,omitempty"`
-	Status    SettlementStatus  `json:"status,omitempty"`
-	Links     SettlementLinks   `json:"_links,omitempty"`
+	ID        string             `json:"id,omitempty"`
+	Resource  string             `json:"resource,omitempty"`
+	Reference string             `json:"reference,omitempty"`
+	InvoiceID string             `json:"invoiceId,omitempty"`
+	CreatedAt *time.Time         `json:"createdAt,omitempty"`
+	SettledAt *time.Time         `json:"settledAt,omitempty"`
+	Amount    *Amount            `json:"amount,omitempty"`
+	Periods   *SettlementObject  `json:"periods,omitempty"`
+	Status    SettlementStatus   `json:"status,omitempty"`
+	Links     SettlementLinks    `json:"_links,omitempty"`
+	Embedded  SettlementEmbedded `json:"_embedded,omitempty"`
+}
+
+// SettlementEmbedded contains the resources embedded in a settlement when
+// requested using the embed option.
+type SettlementEmbedded struct {
+	Payments    []*Payment    `json:"payments,omitempty"`
+	Refunds     []*Refund     `json:"refunds,omitempty"`
+	Chargebacks []*Chargeback `json:"chargebacks,omitempty"`
+	Captures    []*Capture    `json:"captures,omitempty"`
+}
+
+// SettlementsOptions contains query parameters to get a settlement.
+//
+// See: https://docs.mollie.com/reference/get-settlement
+type SettlementsOptions struct {
+	Embed []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // ListSettlementsOptions contains query parameters for settlement lists.
 type ListSettlementsOptions struct {
 	From  string       `url:"from,omitempty"`
 	Limit int          `url:"limit,omitempty"`
-	Embed []EmbedValue `url:"embed,omitempty"`
+	Embed []EmbedValue `url:"embed,omitempty,comma"`
 }
 
 // SettlementsList describes a list of settlements.
@@ -100,14 +118,26 @@ type SettlementsService service
 //
 // See: https://docs.mollie.com/reference/get-settlement
 func (ss *SettlementsService) Get(ctx context.Context, settlement string) (res *Response, s *Settlement, err error) {
-	return ss.get(ctx, settlement)
+	return ss.get(ctx, settlement, nil)
+}
+
+// GetWithOptions returns a settlement by its id or the bank reference id,
+// embedding the resources requested in opts.
+//
+// See: https://docs.mollie.com/reference/get-settlement
+func (ss *SettlementsService) GetWithOptions(ctx context.Context, settlement string, opts *SettlementsOptions) (
+	res *Response,
+	s *Settlement,
+	err error,
+) {
+	return ss.get(ctx, settlement, opts)
 }
 
 // Next retrieves the details of the current settlement that has not yet been paid out.
 //
 // See: https://docs.mollie.com/reference/get-next-settlement
 func (ss *SettlementsService) Next(ctx context.Context) (res *Response, s *Settlement, err error) {
-	return ss.get(ctx, "next")
+	return ss.get(ctx, "next", nil)
 }
 
 // Open retrieves the details of the open balance of the organization.
@@ -115,7 +145,7 @@ func (ss *SettlementsService) Next(ctx context.Context) (res *Response, s *Settl
 //
 // See: https://docs.mollie.com/reference/get-open-settlement
 func (ss *SettlementsService) Open(ctx context.Context) (res *Response, s *Settlement, err error) {
-	return ss.get(ctx, "open")
+	return ss.get(ctx, "open", nil)
 }
 
 // List retrieves all settlements, ordered from new to old
@@ -138,6 +168,13 @@ func (ss *SettlementsService) List(ctx context.Context, slo *ListSettlementsOpti
 	return
 }
 
+// All returns an iterator over all settlements, ordered from new to old.
+func (ss *SettlementsService) All(ctx context.Context, slo *ListSettlementsOptions) iter.Seq2[*Settlement, error] {
+	return paginate(ctx, ss.client, "v2/settlements", slo, func(l *SettlementsList) ([]*Settlement, *URL) {
+		return l.Embedded.Settlements, l.Links.Next
+	})
+}
+
 // ListPayments retrieves all payments included in a settlement.
 // This API is an alias of the List payments.
 //
@@ -159,6 +196,19 @@ func (ss *SettlementsService) ListPayments(ctx context.Context, settlement strin
 	return
 }
 
+// AllPayments returns an iterator over all payments included in a settlement.
+func (ss *SettlementsService) AllPayments(
+	ctx context.Context,
+	settlement string,
+	options *ListPaymentsOptions,
+) iter.Seq2[*Payment, error] {
+	u := fmt.Sprintf("v2/settlements/%s/payments", settlement)
+
+	return paginate(ctx, ss.client, u, options, func(l *PaymentList) ([]*Payment, *URL) {
+		return l.Embedded.Payments, l.Links.Next
+	})
+}
+
 // GetRefunds retrieves all refunds included in a settlement.
 //
 // See: https://docs.mollie.com/reference/list-settlement-refunds
@@ -179,6 +229,19 @@ func (ss *SettlementsService) GetRefunds(ctx context.Context, settlement string,
 	return
 }
 
+// AllRefunds returns an iterator over all refunds included in a settlement.
+func (ss *SettlementsService) AllRefunds(
+	ctx context.Context,
+	settlement string,
+	slo *ListSettlementsOptions,
+) iter.Seq2[*Refund, error] {
+	u := fmt.Sprintf("v2/settlements/%s/refunds", settlement)
+
+	return paginate(ctx, ss.client, u, slo, func(l *RefundsList) ([]*Refund, *URL) {
+		return l.Embedded.Refunds, l.Links.Next
+	})
+}
+
 // GetChargebacks retrieves all chargebacks included in a settlement.
 //
 // See: https://docs.mollie.com/reference/list-settlement-chargebacks
@@ -199,6 +262,19 @@ func (ss *SettlementsService) GetChargebacks(ctx context.Context, settlement str
 	return
 }
 
+// AllChargebacks returns an iterator over all chargebacks included in a settlement.
+func (ss *SettlementsService) AllChargebacks(
+	ctx context.Context,
+	settlement string,
+	slo *ListChargebacksOptions,
+) iter.Seq2[*Chargeback, error] {
+	u := fmt.Sprintf("v2/settlements/%s/chargebacks", settlement)
+
+	return paginate(ctx, ss.client, u, slo, func(l *ChargebacksList) ([]*Chargeback, *URL) {
+		return l.Embedded.Chargebacks, l.Links.Next
+	})
+}
+
 // GetCaptures retrieves all captures included in a settlement.
 //
 // See: https://docs.mollie.com/reference/list-settlement-captures
@@ -219,8 +295,25 @@ func (ss *SettlementsService) GetCaptures(ctx context.Context, settlement string
 	return
 }
 
-func (ss *SettlementsService) get(ctx context.Context, element string) (res *Response, s *Settlement, err error) {
-	res, err = ss.client.get(ctx, fmt.Sprintf("v2/settlements/%s", element), nil)
+// AllCaptures returns an iterator over all captures included in a settlement.
+func (ss *SettlementsService) AllCaptures(
+	ctx context.Context,
+	settlement string,
+	slo *ListSettlementsOptions,
+) iter.Seq2[*Capture, error] {
+	u := fmt.Sprintf("v2/settlements/%s/captures", settlement)
+
+	return paginate(ctx, ss.client, u, slo, func(l *CapturesList) ([]*Capture, *URL) {
+		return l.Embedded.Captures, l.Links.Next
+	})
+}
+
+func (ss *SettlementsService) get(ctx context.Context, element string, opts *SettlementsOptions) (
+	res *Response,
+	s *Settlement,
+	err error,
+) {
+	res, err = ss.client.get(ctx, fmt.Sprintf("v2/settlements/%s", element), opts)
 	if err != nil {
 		return
 	}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSettlementsService_Get(t *testing.T) {
@@ -802,3 +803,28 @@ func TestSettlementsService_GetRefunds(t *testing.T) {
 		})
 	}
 }
+
+func TestSettlementsService_GetEmbedded(t *testing.T) {
+	setEnv()
+	setup()
+	defer func() {
+		teardown()
+		unsetEnv()
+	}()
+
+	tMux.HandleFunc("/v2/settlements/stl_jDk30akdN", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testQuery(t, r, "embed=payments%2Crefunds%2Cchargebacks%2Ccaptures")
+		_, _ = w.Write([]byte(testdata.GetSettlementEmbeddedResponse))
+	})
+
+	_, s, err := tClient.Settlements.GetWithOptions(context.Background(), "stl_jDk30akdN", &SettlementsOptions{
+		Embed: []EmbedValue{EmbedPayments, EmbedRefunds, EmbedChargebacks, EmbedCaptures},
+	})
+	require.Nil(t, err)
+	require.Len(t, s.Embedded.Payments, 1)
+	require.Len(t, s.Embedded.Refunds, 1)
+	require.Len(t, s.Embedded.Chargebacks, 1)
+	require.Len(t, s.Embedded.Captures, 1)
+	assert.Equal(t, "stl_jDk30akdN", s.Embedded.Captures[0].SettlementID)
+}
@@ -98,10 +98,6 @@ func (ss *ShipmentsService) Create(ctx context.Context, order string, cs CreateS
 ) {
 	uri := fmt.Sprintf("v2/orders/%s/shipments", order)
 
-	if ss.client.HasAccessToken() && ss.client.config.testing {
-		cs.Testmode = true
-	}
-
 	res, err = ss.client.post(ctx, uri, cs, nil)
 	if err != nil {
 		return
@@ -264,7 +264,7 @@ func TestShipmentsService_Create(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -396,7 +396,7 @@ func TestShipmentsService_Update(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "PATCH")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
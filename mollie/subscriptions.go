@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -138,10 +139,6 @@ func (ss *SubscriptionsService) Create(ctx context.Context, customer string, sc
 ) {
 	uri := fmt.Sprintf("v2/customers/%s/subscriptions", customer)
 
-	if ss.client.HasAccessToken() && ss.client.config.testing {
-		sc.Testmode = true
-	}
-
 	res, err = ss.client.post(ctx, uri, sc, nil)
 	if err != nil {
 		return
@@ -222,6 +219,18 @@ func (ss *SubscriptionsService) All(ctx context.Context, opts *ListSubscriptions
 	return
 }
 
+// AllSubscriptions returns an iterator over all subscriptions, ordered from newest to oldest.
+//
+// It is the iterator version of All, which only retrieves a single page.
+func (ss *SubscriptionsService) AllSubscriptions(
+	ctx context.Context,
+	opts *ListSubscriptionsOptions,
+) iter.Seq2[*Subscription, error] {
+	return paginate(ctx, ss.client, "v2/subscriptions", opts, func(l *SubscriptionsList) ([]*Subscription, *URL) {
+		return l.Embedded.Subscriptions, l.Links.Next
+	})
+}
+
 // List retrieves all subscriptions of a customer
 //
 // See: https://docs.mollie.com/reference/list-subscriptions
@@ -244,6 +253,19 @@ func (ss *SubscriptionsService) List(ctx context.Context, customer string, opts
 	return
 }
 
+// AllForCustomer returns an iterator over all subscriptions of a customer.
+func (ss *SubscriptionsService) AllForCustomer(
+	ctx context.Context,
+	customer string,
+	opts *ListSubscriptionsOptions,
+) iter.Seq2[*Subscription, error] {
+	u := fmt.Sprintf("v2/customers/%s/subscriptions", customer)
+
+	return paginate(ctx, ss.client, u, opts, func(l *SubscriptionsList) ([]*Subscription, *URL) {
+		return l.Embedded.Subscriptions, l.Links.Next
+	})
+}
+
 // ListPayments retrieves all payments of a specific subscriptions of a customer
 //
 // See: https://docs.mollie.com/reference/list-subscription-payments
@@ -270,6 +292,19 @@ func (ss *SubscriptionsService) ListPayments(
 	return
 }
 
+// AllPayments returns an iterator over all payments of a specific subscription of a customer.
+func (ss *SubscriptionsService) AllPayments(
+	ctx context.Context,
+	customer, subscription string,
+	opts *ListSubscriptionsOptions,
+) iter.Seq2[*Payment, error] {
+	u := fmt.Sprintf("v2/customers/%s/subscriptions/%s/payments", customer, subscription)
+
+	return paginate(ctx, ss.client, u, opts, func(l *PaymentList) ([]*Payment, *URL) {
+		return l.Embedded.Payments, l.Links.Next
+	})
+}
+
 func (ss *SubscriptionsService) list(ctx context.Context, uri string, opts interface{}) (r *Response, err error) {
 	r, err = ss.client.get(ctx, uri, opts)
 	if err != nil {
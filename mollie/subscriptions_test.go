@@ -168,7 +168,7 @@ func TestSubscriptionsService_Create(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "POST")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -318,7 +318,7 @@ func TestSubscriptionsService_Update(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "PATCH")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -456,7 +456,7 @@ func TestSubscriptionsService_Cancel(t *testing.T) {
 			func(w http.ResponseWriter, r *http.Request) {
 				testHeader(t, r, AuthHeader, "Bearer access_token_test")
 				testMethod(t, r, "DELETE")
-				testQuery(t, r, "testmode=true")
+				testTestmodeBody(t, r)
 
 				if _, ok := r.Header[AuthHeader]; !ok {
 					w.WriteHeader(http.StatusUnauthorized)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -75,10 +76,6 @@ func (ts *TerminalsService) List(ctx context.Context, options *ListTerminalsOpti
 	tl *TerminalList,
 	err error,
 ) {
-	if ts.client.HasAccessToken() && ts.client.config.testing {
-		options.Testmode = true
-	}
-
 	res, err = ts.client.get(ctx, "v2/terminals", options)
 	if err != nil {
 		return
@@ -90,3 +87,10 @@ func (ts *TerminalsService) List(ctx context.Context, options *ListTerminalsOpti
 
 	return
 }
+
+// All returns an iterator over all the terminals.
+func (ts *TerminalsService) All(ctx context.Context, options *ListTerminalsOptions) iter.Seq2[*Terminal, error] {
+	return paginate(ctx, ts.client, "v2/terminals", options, func(l *TerminalList) ([]*Terminal, *URL) {
+		return l.Embedded.Terminals, l.Links.Next
+	})
+}
@@ -0,0 +1,444 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type serviceCall struct {
+	name string
+	call func(ctx context.Context, c *Client) error
+}
+
+func drop[T any](_ *Response, _ T, err error) error {
+	return err
+}
+
+// serviceCalls contains a call to every endpoint of every service.
+func serviceCalls() []serviceCall {
+	amount := &Amount{Currency: "EUR", Value: "10.00"}
+
+	return []serviceCall{
+		{"Balances.Get", func(ctx context.Context, c *Client) error { return drop(c.Balances.Get(ctx, "bal_1")) }},
+		{"Balances.Primary", func(ctx context.Context, c *Client) error { return drop(c.Balances.Primary(ctx)) }},
+		{"Balances.List", func(ctx context.Context, c *Client) error { return drop(c.Balances.List(ctx, nil)) }},
+		{"Balances.GetReport", func(ctx context.Context, c *Client) error {
+			return drop(c.Balances.GetReport(ctx, "bal_1", nil))
+		}},
+		{"Balances.GetPrimaryReport", func(ctx context.Context, c *Client) error {
+			return drop(c.Balances.GetPrimaryReport(ctx, nil))
+		}},
+		{"Balances.GetTransactionsList", func(ctx context.Context, c *Client) error {
+			return drop(c.Balances.GetTransactionsList(ctx, "bal_1", nil))
+		}},
+		{"Balances.GetPrimaryTransactionsList", func(ctx context.Context, c *Client) error {
+			return drop(c.Balances.GetPrimaryTransactionsList(ctx, nil))
+		}},
+		{"Captures.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Captures.Get(ctx, "tr_1", "cpt_1", nil))
+		}},
+		{"Captures.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Captures.Create(ctx, "tr_1", CreateCapture{Amount: amount}))
+		}},
+		{"Captures.List", func(ctx context.Context, c *Client) error {
+			return drop(c.Captures.List(ctx, "tr_1", nil))
+		}},
+		{"Chargebacks.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Chargebacks.Get(ctx, "tr_1", "chb_1", nil))
+		}},
+		{"Chargebacks.List", func(ctx context.Context, c *Client) error { return drop(c.Chargebacks.List(ctx, nil)) }},
+		{"Chargebacks.ListForPayment", func(ctx context.Context, c *Client) error {
+			return drop(c.Chargebacks.ListForPayment(ctx, "tr_1", nil))
+		}},
+		{"ClientLinks.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.ClientLinks.Create(ctx, CreateClientLink{Name: "Acme"}))
+		}},
+		{"Clients.List", func(ctx context.Context, c *Client) error { return drop(c.Clients.List(ctx, nil)) }},
+		{"Clients.Get", func(ctx context.Context, c *Client) error { return drop(c.Clients.Get(ctx, "org_1", nil)) }},
+		{"Customers.Get", func(ctx context.Context, c *Client) error { return drop(c.Customers.Get(ctx, "cst_1")) }},
+		{"Customers.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Customers.Create(ctx, CreateCustomer{Name: "Jane"}))
+		}},
+		{"Customers.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Customers.Update(ctx, "cst_1", UpdateCustomer{Name: "Jane"}))
+		}},
+		{"Customers.Delete", func(ctx context.Context, c *Client) error {
+			_, err := c.Customers.Delete(ctx, "cst_1")
+
+			return err
+		}},
+		{"Customers.List", func(ctx context.Context, c *Client) error { return drop(c.Customers.List(ctx, nil)) }},
+		{"Customers.GetPayments", func(ctx context.Context, c *Client) error {
+			return drop(c.Customers.GetPayments(ctx, "cst_1", nil))
+		}},
+		{"Customers.CreatePayment", func(ctx context.Context, c *Client) error {
+			return drop(c.Customers.CreatePayment(ctx, "cst_1", CreatePayment{Amount: amount}))
+		}},
+		{"DelayedRouting.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.DelayedRouting.Create(ctx, "tr_1", CreateDelayedRouting{Amount: *amount}))
+		}},
+		{"DelayedRouting.List", func(ctx context.Context, c *Client) error {
+			return drop(c.DelayedRouting.List(ctx, "tr_1"))
+		}},
+		{"Invoices.Get", func(ctx context.Context, c *Client) error { return drop(c.Invoices.Get(ctx, "inv_1")) }},
+		{"Invoices.List", func(ctx context.Context, c *Client) error { return drop(c.Invoices.List(ctx, nil)) }},
+		{"Mandates.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Mandates.Create(ctx, "cst_1", CreateMandate{Method: DirectDebit}))
+		}},
+		{"Mandates.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Mandates.Get(ctx, "cst_1", "mdt_1"))
+		}},
+		{"Mandates.Revoke", func(ctx context.Context, c *Client) error {
+			_, err := c.Mandates.Revoke(ctx, "cst_1", "mdt_1")
+
+			return err
+		}},
+		{"Mandates.List", func(ctx context.Context, c *Client) error {
+			return drop(c.Mandates.List(ctx, "cst_1", nil))
+		}},
+		{"Onboarding.GetOnboardingStatus", func(ctx context.Context, c *Client) error {
+			return drop(c.Onboarding.GetOnboardingStatus(ctx))
+		}},
+		{"Onboarding.SubmitOnboardingData", func(ctx context.Context, c *Client) error {
+			_, err := c.Onboarding.SubmitOnboardingData(ctx, &OnboardingData{})
+
+			return err
+		}},
+		{"Orders.Get", func(ctx context.Context, c *Client) error { return drop(c.Orders.Get(ctx, "ord_1", nil)) }},
+		{"Orders.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.Create(ctx, CreateOrder{Amount: amount}, nil))
+		}},
+		{"Orders.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.Update(ctx, "ord_1", UpdateOrder{OrderNumber: "1337"}))
+		}},
+		{"Orders.Cancel", func(ctx context.Context, c *Client) error { return drop(c.Orders.Cancel(ctx, "ord_1")) }},
+		{"Orders.List", func(ctx context.Context, c *Client) error { return drop(c.Orders.List(ctx, nil)) }},
+		{"Orders.UpdateOrderLine", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.UpdateOrderLine(ctx, "ord_1", "odl_1", UpdateOrderLine{Name: "LEGO"}))
+		}},
+		{"Orders.CancelOrderLines", func(ctx context.Context, c *Client) error {
+			_, err := c.Orders.CancelOrderLines(ctx, "ord_1", []OrderLine{{ID: "odl_1"}})
+
+			return err
+		}},
+		{"Orders.CreateOrderPayment", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.CreateOrderPayment(ctx, "ord_1", &OrderPayment{}))
+		}},
+		{"Orders.CreateOrderRefund", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.CreateOrderRefund(ctx, "ord_1", &Order{}))
+		}},
+		{"Orders.ListOrderRefunds", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.ListOrderRefunds(ctx, "ord_1", nil))
+		}},
+		{"Orders.ManageOrderLines", func(ctx context.Context, c *Client) error {
+			return drop(c.Orders.ManageOrderLines(ctx, "ord_1", &OrderLineOperations{}))
+		}},
+		{"Organizations.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Organizations.Get(ctx, "org_1"))
+		}},
+		{"Organizations.GetCurrent", func(ctx context.Context, c *Client) error {
+			return drop(c.Organizations.GetCurrent(ctx))
+		}},
+		{"Organizations.GetPartnerStatus", func(ctx context.Context, c *Client) error {
+			return drop(c.Organizations.GetPartnerStatus(ctx))
+		}},
+		{"PaymentLinks.Get", func(ctx context.Context, c *Client) error { return drop(c.PaymentLinks.Get(ctx, "pl_1")) }},
+		{"PaymentLinks.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentLinks.Create(ctx, PaymentLink{Description: "Order"}, nil))
+		}},
+		{"PaymentLinks.List", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentLinks.List(ctx, nil))
+		}},
+		{"PaymentLinks.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentLinks.Update(ctx, "pl_1", UpdatePaymentLinks{Description: "Order"}))
+		}},
+		{"PaymentLinks.Delete", func(ctx context.Context, c *Client) error {
+			_, err := c.PaymentLinks.Delete(ctx, "pl_1")
+
+			return err
+		}},
+		{"PaymentLinks.Payments", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentLinks.Payments(ctx, "pl_1", nil))
+		}},
+		{"PaymentMethods.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentMethods.Get(ctx, IDeal, nil))
+		}},
+		{"PaymentMethods.All", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentMethods.All(ctx, nil))
+		}},
+		{"PaymentMethods.List", func(ctx context.Context, c *Client) error {
+			return drop(c.PaymentMethods.List(ctx, nil))
+		}},
+		{"Payments.Get", func(ctx context.Context, c *Client) error { return drop(c.Payments.Get(ctx, "tr_1", nil)) }},
+		{"Payments.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Payments.Create(ctx, CreatePayment{Amount: amount}, nil))
+		}},
+		{"Payments.Cancel", func(ctx context.Context, c *Client) error { return drop(c.Payments.Cancel(ctx, "tr_1")) }},
+		{"Payments.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Payments.Update(ctx, "tr_1", UpdatePayment{Description: "Order"}))
+		}},
+		{"Payments.List", func(ctx context.Context, c *Client) error { return drop(c.Payments.List(ctx, nil)) }},
+		{"Permissions.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Permissions.Get(ctx, PaymentsRead))
+		}},
+		{"Permissions.List", func(ctx context.Context, c *Client) error { return drop(c.Permissions.List(ctx)) }},
+		{"Profiles.List", func(ctx context.Context, c *Client) error { return drop(c.Profiles.List(ctx, nil)) }},
+		{"Profiles.Get", func(ctx context.Context, c *Client) error { return drop(c.Profiles.Get(ctx, "pfl_1")) }},
+		{"Profiles.Current", func(ctx context.Context, c *Client) error { return drop(c.Profiles.Current(ctx)) }},
+		{"Profiles.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.Create(ctx, CreateOrUpdateProfile{Name: "Acme"}))
+		}},
+		{"Profiles.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.Update(ctx, "pfl_1", CreateOrUpdateProfile{Name: "Acme"}))
+		}},
+		{"Profiles.Delete", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.Delete(ctx, "pfl_1")
+
+			return err
+		}},
+		{"Profiles.EnablePaymentMethod", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.EnablePaymentMethod(ctx, "pfl_1", IDeal))
+		}},
+		{"Profiles.DisablePaymentMethod", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.DisablePaymentMethod(ctx, "pfl_1", IDeal)
+
+			return err
+		}},
+		{"Profiles.EnableGiftCardIssuer", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.EnableGiftCardIssuer(ctx, "pfl_1", Boekenbon))
+		}},
+		{"Profiles.DisableGiftCardIssuer", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.DisableGiftCardIssuer(ctx, "pfl_1", Boekenbon)
+
+			return err
+		}},
+		{"Profiles.EnableGiftCardIssuerForCurrent", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.EnableGiftCardIssuerForCurrent(ctx, Boekenbon))
+		}},
+		{"Profiles.DisableGiftCardIssuerForCurrent", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.DisableGiftCardIssuerForCurrent(ctx, Boekenbon)
+
+			return err
+		}},
+		{"Profiles.EnableVoucherIssuer", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.EnableVoucherIssuer(ctx, "pfl_1", EdenredBelgiumMealVoucher, &EnableVoucherIssuer{}))
+		}},
+		{"Profiles.DisableVoucherIssuer", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.DisableVoucherIssuer(ctx, "pfl_1", EdenredBelgiumMealVoucher)
+
+			return err
+		}},
+		{"Profiles.EnableVoucherIssuerForCurrent", func(ctx context.Context, c *Client) error {
+			return drop(c.Profiles.EnableVoucherIssuerForCurrent(ctx, EdenredBelgiumMealVoucher))
+		}},
+		{"Profiles.DisableVoucherIssuerForCurrent", func(ctx context.Context, c *Client) error {
+			_, err := c.Profiles.DisableVoucherIssuerForCurrent(ctx, EdenredBelgiumMealVoucher)
+
+			return err
+		}},
+		{"Refunds.List", func(ctx context.Context, c *Client) error { return drop(c.Refunds.List(ctx, nil)) }},
+		{"Refunds.GetPaymentRefund", func(ctx context.Context, c *Client) error {
+			return drop(c.Refunds.GetPaymentRefund(ctx, "tr_1", "re_1", nil))
+		}},
+		{"Refunds.ListPaymentRefunds", func(ctx context.Context, c *Client) error {
+			return drop(c.Refunds.ListPaymentRefunds(ctx, "tr_1", nil))
+		}},
+		{"Refunds.CreatePaymentRefund", func(ctx context.Context, c *Client) error {
+			return drop(c.Refunds.CreatePaymentRefund(ctx, "tr_1", CreatePaymentRefund{Amount: amount}, nil))
+		}},
+		{"Refunds.CancelPaymentRefund", func(ctx context.Context, c *Client) error {
+			_, err := c.Refunds.CancelPaymentRefund(ctx, "tr_1", "re_1")
+
+			return err
+		}},
+		{"Refunds.CreateOrderRefund", func(ctx context.Context, c *Client) error {
+			return drop(c.Refunds.CreateOrderRefund(ctx, "ord_1", CreateOrderRefund{Description: "Refund"}))
+		}},
+		{"Refunds.ListOrderRefunds", func(ctx context.Context, c *Client) error {
+			return drop(c.Refunds.ListOrderRefunds(ctx, "ord_1", nil))
+		}},
+		{"SalesInvoices.List", func(ctx context.Context, c *Client) error {
+			return drop(c.SalesInvoices.List(ctx, nil))
+		}},
+		{"SalesInvoices.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.SalesInvoices.Get(ctx, "invoice_1"))
+		}},
+		{"SalesInvoices.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.SalesInvoices.Create(ctx, CreateSalesInvoice{Memo: "Order"}))
+		}},
+		{"SalesInvoices.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.SalesInvoices.Update(ctx, "invoice_1", UpdateSalesInvoice{Memo: "Order"}))
+		}},
+		{"SalesInvoices.Delete", func(ctx context.Context, c *Client) error {
+			_, err := c.SalesInvoices.Delete(ctx, "invoice_1")
+
+			return err
+		}},
+		{"Settlements.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Settlements.Get(ctx, "stl_1"))
+		}},
+		{"Settlements.Next", func(ctx context.Context, c *Client) error { return drop(c.Settlements.Next(ctx)) }},
+		{"Settlements.Open", func(ctx context.Context, c *Client) error { return drop(c.Settlements.Open(ctx)) }},
+		{"Settlements.List", func(ctx context.Context, c *Client) error { return drop(c.Settlements.List(ctx, nil)) }},
+		{"Settlements.ListPayments", func(ctx context.Context, c *Client) error {
+			return drop(c.Settlements.ListPayments(ctx, "stl_1", nil))
+		}},
+		{"Settlements.GetRefunds", func(ctx context.Context, c *Client) error {
+			return drop(c.Settlements.GetRefunds(ctx, "stl_1", nil))
+		}},
+		{"Settlements.GetChargebacks", func(ctx context.Context, c *Client) error {
+			return drop(c.Settlements.GetChargebacks(ctx, "stl_1", nil))
+		}},
+		{"Settlements.GetCaptures", func(ctx context.Context, c *Client) error {
+			return drop(c.Settlements.GetCaptures(ctx, "stl_1", nil))
+		}},
+		{"Shipments.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Shipments.Get(ctx, "ord_1", "shp_1"))
+		}},
+		{"Shipments.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Shipments.Create(ctx, "ord_1", CreateShipment{}))
+		}},
+		{"Shipments.List", func(ctx context.Context, c *Client) error { return drop(c.Shipments.List(ctx, "ord_1")) }},
+		{"Shipments.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Shipments.Update(ctx, "ord_1", "shp_1", UpdateShipment{}))
+		}},
+		{"Subscriptions.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.Get(ctx, "cst_1", "sub_1"))
+		}},
+		{"Subscriptions.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.Create(ctx, "cst_1", CreateSubscription{Amount: amount}))
+		}},
+		{"Subscriptions.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.Update(ctx, "cst_1", "sub_1", UpdateSubscription{Description: "Plan"}))
+		}},
+		{"Subscriptions.Cancel", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.Cancel(ctx, "cst_1", "sub_1"))
+		}},
+		{"Subscriptions.All", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.All(ctx, nil))
+		}},
+		{"Subscriptions.List", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.List(ctx, "cst_1", nil))
+		}},
+		{"Subscriptions.ListPayments", func(ctx context.Context, c *Client) error {
+			return drop(c.Subscriptions.ListPayments(ctx, "cst_1", "sub_1", nil))
+		}},
+		{"Terminals.Get", func(ctx context.Context, c *Client) error { return drop(c.Terminals.Get(ctx, "term_1")) }},
+		{"Terminals.List", func(ctx context.Context, c *Client) error { return drop(c.Terminals.List(ctx, nil)) }},
+		{"Wallets.ApplePaymentSession", func(ctx context.Context, c *Client) error {
+			return drop(c.Wallets.ApplePaymentSession(ctx, &ApplePaymentSessionRequest{Domain: "example.org"}))
+		}},
+		{"WebhookEvents.Get", func(ctx context.Context, c *Client) error {
+			return drop(c.WebhookEvents.Get(ctx, "event_1"))
+		}},
+		{"Webhooks.Create", func(ctx context.Context, c *Client) error {
+			return drop(c.Webhooks.Create(ctx, CreateWebhook{Name: "Hook"}))
+		}},
+		{"Webhooks.Get", func(ctx context.Context, c *Client) error { return drop(c.Webhooks.Get(ctx, "hook_1")) }},
+		{"Webhooks.Update", func(ctx context.Context, c *Client) error {
+			return drop(c.Webhooks.Update(ctx, "hook_1", UpdateWebhook{Name: "Hook"}))
+		}},
+		{"Webhooks.List", func(ctx context.Context, c *Client) error { return drop(c.Webhooks.List(ctx, nil)) }},
+		{"Webhooks.Delete", func(ctx context.Context, c *Client) error {
+			_, err := c.Webhooks.Delete(ctx, "hook_1")
+
+			return err
+		}},
+		{"Webhooks.Test", func(ctx context.Context, c *Client) error {
+			_, err := c.Webhooks.Test(ctx, "hook_1")
+
+			return err
+		}},
+	}
+}
+
+type recordedRequest struct {
+	method string
+	query  map[string][]string
+	body   []byte
+}
+
+func recordRequests(t *testing.T) *[]recordedRequest {
+	t.Helper()
+
+	var reqs []recordedRequest
+
+	tMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.Nil(t, err)
+
+		reqs = append(reqs, recordedRequest{r.Method, r.URL.Query(), b})
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	return &reqs
+}
+
+func TestTestmode_AllServices(t *testing.T) {
+	for _, sc := range serviceCalls() {
+		t.Run(sc.name, func(t *testing.T) {
+			setEnv()
+			setup()
+			defer teardown()
+			defer unsetEnv()
+
+			reqs := recordRequests(t)
+			require.Nil(t, tClient.WithAuthenticationValue("access_X12b31ggg23"))
+			require.Nil(t, sc.call(context.Background(), tClient))
+			require.Len(t, *reqs, 1)
+
+			req := (*reqs)[0]
+			if req.method == http.MethodGet {
+				assert.Equal(t, []string{"true"}, req.query["testmode"])
+				assert.Empty(t, req.body)
+
+				return
+			}
+
+			assert.NotContains(t, req.query, "testmode")
+
+			var body map[string]any
+			require.Nil(t, json.Unmarshal(req.body, &body), string(req.body))
+			assert.Equal(t, true, body["testmode"])
+		})
+	}
+}
+
+func TestTestmode_NotInjected(t *testing.T) {
+	cases := []struct {
+		name  string
+		token string
+		conf  func(reqIdem bool) *Config
+	}{
+		{"api keys select the mode themselves", "test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM", NewAPITestingConfig},
+		{"live configurations", "access_X12b31ggg23", NewOrgConfig},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			setEnv()
+			setup()
+			defer teardown()
+			defer unsetEnv()
+
+			tClient.config = c.conf(false)
+			reqs := recordRequests(t)
+			require.Nil(t, tClient.WithAuthenticationValue(c.token))
+
+			for _, sc := range serviceCalls() {
+				require.Nil(t, sc.call(context.Background(), tClient), sc.name)
+			}
+
+			for _, req := range *reqs {
+				assert.NotContains(t, req.query, "testmode")
+				assert.NotContains(t, string(req.body), "testmode")
+			}
+		})
+	}
+}
@@ -0,0 +1,470 @@
+package mollie
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Validator is implemented by the payloads that can be checked before being
+// sent to Mollie.
+//
+// When validation is enabled in the Config, requests with a payload failing
+// validation are not sent and a *ValidationError is returned instead.
+type Validator interface {
+	Validate() error
+}
+
+// FieldError describes why a field of a payload is invalid.
+type FieldError struct {
+	Field  string
+	Reason string
+}
+
+// Error interface compliance.
+func (fe FieldError) Error() string {
+	return fe.Field + " " + fe.Reason
+}
+
+// ValidationError lists every field of a payload failing validation.
+//
+// It matches ErrUnprocessable, like the 422 responses returned by Mollie
+// for the same payloads.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error interface compliance.
+func (ve *ValidationError) Error() string {
+	reasons := make([]string, len(ve.Fields))
+	for i, f := range ve.Fields {
+		reasons[i] = f.Error()
+	}
+
+	return "validation failed: " + strings.Join(reasons, "; ")
+}
+
+// Is reports whether target is ErrUnprocessable.
+func (ve *ValidationError) Is(target error) bool {
+	return target == ErrUnprocessable
+}
+
+var intervalExpr = regexp.MustCompile(`^[1-9][0-9]* (days?|weeks?|months?)$`)
+
+// validation collects the field errors of a payload.
+type validation struct {
+	errs []FieldError
+}
+
+func (v *validation) add(field, format string, args ...any) {
+	v.errs = append(v.errs, FieldError{field, fmt.Sprintf(format, args...)})
+}
+
+func (v *validation) required(field string, ok bool) {
+	if !ok {
+		v.add(field, "is required")
+	}
+}
+
+// amount checks that a has a known currency and a non negative value with
+// the exact number of decimals of its currency, e.g. 10.00 for EUR.
+func (v *validation) amount(field string, a *Amount, required bool) (Money, bool) {
+	return v.lineAmount(field, a, required, false)
+}
+
+// lineAmount checks a like amount, negative values are accepted when
+// negative is true.
+func (v *validation) lineAmount(field string, a *Amount, required, negative bool) (Money, bool) {
+	if a == nil || (a.Currency == "" && a.Value == "") {
+		v.required(field, !required)
+
+		return Money{}, false
+	}
+
+	m, err := a.Money()
+	if err != nil || m.String() != a.Value {
+		v.add(field, "must be a value with %d decimals in a valid currency", CurrencyDecimals(a.Currency))
+
+		return Money{}, false
+	}
+
+	if m.IsNegative() && !negative {
+		v.add(field, "must not be negative")
+
+		return Money{}, false
+	}
+
+	return m, true
+}
+
+// negativeLine reports if the amounts of a line of the given type are
+// negative, discounts, store credit and gift cards reduce the total.
+func negativeLine(kind string) bool {
+	switch kind {
+	case string(DiscountProductLine), string(StoreCreditLine), string(GiftCardLine):
+		return true
+	default:
+		return false
+	}
+}
+
+func (v *validation) url(field, raw string, required bool) {
+	if raw == "" {
+		v.required(field, !required)
+
+		return
+	}
+
+	if u, err := url.Parse(raw); err != nil || !u.IsAbs() || u.Host == "" {
+		v.add(field, "must be an absolute URL")
+	}
+}
+
+func (v *validation) interval(field, interval string, required bool) {
+	if interval == "" {
+		v.required(field, !required)
+
+		return
+	}
+
+	if !intervalExpr.MatchString(interval) {
+		v.add(field, "must be formatted as <number> days, weeks or months")
+	}
+}
+
+// address applies the all-or-nothing rule described on the Address type:
+// once any field is provided the street, postal code, city and country are
+// required.
+func (v *validation) address(prefix string, fields map[string]string, required bool) {
+	provided := false
+
+	for _, value := range fields {
+		provided = provided || value != ""
+	}
+
+	if !provided && !required {
+		return
+	}
+
+	for _, f := range []string{"streetAndNumber", "postalCode", "city", "country"} {
+		v.required(prefix+"."+f, fields[f] != "")
+	}
+
+	if c := fields["country"]; c != "" && (len(c) != 2 || strings.ToUpper(c) != c) {
+		v.add(prefix+".country", "must be an ISO 3166-1 alpha-2 code")
+	}
+}
+
+func (v *validation) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Fields: v.errs}
+}
+
+func (a *Address) fields() map[string]string {
+	return map[string]string{
+		"givenName":        a.GivenName,
+		"familyName":       a.FamilyName,
+		"streetAndNumber":  a.StreetAndNumber,
+		"streetAdditional": a.StreetAdditional,
+		"postalCode":       a.PostalCode,
+		"city":             a.City,
+		"region":           a.Region,
+		"country":          a.Country,
+	}
+}
+
+func (a *OrderAddress) fields() map[string]string {
+	return map[string]string{
+		"givenName":        a.GivenName,
+		"familyName":       a.FamilyName,
+		"streetAndNumber":  a.StreetAndNumber,
+		"streetAdditional": a.StreetAdditional,
+		"postalCode":       a.PostalCode,
+		"city":             a.City,
+		"region":           a.Region,
+		"country":          a.Country,
+	}
+}
+
+// Validate checks the all-or-nothing rule of addresses: when any field
+// is provided, the street, postal code, city and country are required.
+func (a Address) Validate() error {
+	var v validation
+
+	v.address("address", a.fields(), false)
+
+	return v.err()
+}
+
+// Validate checks the fields documented as required when creating a payment,
+// the address rules and the method specific fields.
+func (cp CreatePayment) Validate() error {
+	var v validation
+
+	v.amount("amount", cp.Amount, true)
+	v.required("description", cp.Description != "")
+	v.url("webhookUrl", cp.WebhookURL, false)
+	v.url("cancelUrl", cp.CancelURL, false)
+
+	// The consumer is not redirected for recurring and Apple Pay payments.
+	needsRedirect := cp.SequenceType != RecurringSequence && cp.ApplePayPaymentToken == ""
+	v.url("redirectUrl", cp.RedirectURL, needsRedirect)
+
+	if cp.SequenceType == RecurringSequence || cp.MandateID != "" {
+		v.required("customerId", cp.CustomerID != "")
+	}
+
+	methodFields := []struct {
+		field  string
+		set    bool
+		method PaymentMethod
+	}{
+		{"cardToken", cp.CardToken != "", CreditCard},
+		{"applePayPaymentToken", cp.ApplePayPaymentToken != "", ApplePay},
+		{"voucherNumber", cp.VoucherNumber != "", Voucher},
+		{"consumerAccount", cp.ConsumerAccount != "", BankTransfer},
+	}
+
+	for _, mf := range methodFields {
+		if mf.set && len(cp.Method) > 0 && !slices.Contains(cp.Method, mf.method) {
+			v.add(mf.field, "can only be used with the %s method", mf.method)
+		}
+	}
+
+	if cp.VoucherPin != "" {
+		v.required("voucherNumber", cp.VoucherNumber != "")
+	}
+
+	if cp.BillingAddress != nil {
+		v.address("billingAddress", cp.BillingAddress.fields(), false)
+	}
+
+	if cp.ShippingAddress != nil {
+		v.address("shippingAddress", cp.ShippingAddress.fields(), false)
+	}
+
+	for i, l := range cp.Lines {
+		prefix := fmt.Sprintf("lines[%d].", i)
+		v.required(prefix+"description", l.Description != "")
+		v.required(prefix+"quantity", l.Quantity > 0)
+		negative := negativeLine(string(l.Type))
+		v.lineAmount(prefix+"unitPrice", l.UnitPrice, true, negative)
+		v.lineAmount(prefix+"totalAmount", l.TotalAmount, true, negative)
+		v.amount(prefix+"discountAmount", l.DiscountAmount, false)
+		v.lineAmount(prefix+"vatAmount", l.VATAmount, false, negative)
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields documented as required when creating an order,
+// including the billing address and the sum of the order lines.
+func (co CreateOrder) Validate() error {
+	var v validation
+
+	total, amountOK := v.amount("amount", co.Amount, true)
+	v.required("orderNumber", co.OrderNumber != "")
+	v.required("locale", co.Locale != "")
+	v.url("redirectUrl", co.RedirectURL, true)
+	v.url("webhookUrl", co.WebhookURL, false)
+	v.url("cancelUrl", co.CancelURL, false)
+
+	if co.BillingAddress == nil {
+		v.required("billingAddress", false)
+	} else {
+		v.required("billingAddress.givenName", co.BillingAddress.GivenName != "")
+		v.required("billingAddress.familyName", co.BillingAddress.FamilyName != "")
+		v.required("billingAddress.email", co.BillingAddress.Email != "")
+		v.address("billingAddress", co.BillingAddress.fields(), true)
+	}
+
+	if co.ShippingAddress != nil {
+		v.address("shippingAddress", co.ShippingAddress.fields(), false)
+	}
+
+	v.required("lines", len(co.Lines) > 0)
+
+	sum, sumOK := Money{currency: total.currency}, amountOK
+
+	for i, l := range co.Lines {
+		prefix := fmt.Sprintf("lines[%d].", i)
+		v.required(prefix+"name", l.Name != "")
+		v.required(prefix+"quantity", l.Quantity > 0)
+		v.required(prefix+"vatRate", l.VatRate != "")
+		negative := negativeLine(string(l.ProductType))
+		v.lineAmount(prefix+"unitPrice", l.UnitPrice, true, negative)
+		v.lineAmount(prefix+"vatAmount", l.VatAmount, true, negative)
+		v.amount(prefix+"discountAmount", l.DiscountAmount, false)
+
+		lineTotal, ok := v.lineAmount(prefix+"totalAmount", l.TotalAmount, true, negative)
+		if !ok || !sumOK {
+			sumOK = false
+
+			continue
+		}
+
+		var err error
+		if sum, err = sum.Add(lineTotal); err != nil {
+			v.add(prefix+"totalAmount", "must use the currency of the order")
+
+			sumOK = false
+		}
+	}
+
+	if sumOK && len(co.Lines) > 0 && sum != total {
+		v.add("amount", "must be equal to the sum of the lines totalAmount (%s)", sum)
+	}
+
+	return v.err()
+}
+
+// Validate checks the amount, interval and description of the subscription.
+func (cs CreateSubscription) Validate() error {
+	var v validation
+
+	v.amount("amount", cs.Amount, true)
+	v.interval("interval", cs.Interval, true)
+	v.required("description", cs.Description != "")
+	v.url("webhookUrl", cs.WebhookURL, false)
+
+	if cs.Times < 0 {
+		v.add("times", "must not be negative")
+	}
+
+	return v.err()
+}
+
+// Validate checks the format of the provided fields.
+func (us UpdateSubscription) Validate() error {
+	var v validation
+
+	v.amount("amount", us.Amount, false)
+	v.interval("interval", us.Interval, false)
+	v.url("webhookUrl", us.WebhookURL, false)
+
+	if us.Times < 0 {
+		v.add("times", "must not be negative")
+	}
+
+	return v.err()
+}
+
+// Validate checks the fields required by each mandate method.
+func (cm CreateMandate) Validate() error {
+	var v validation
+
+	v.required("consumerName", cm.ConsumerName != "")
+
+	switch cm.Method {
+	case DirectDebit:
+		v.required("consumerAccount", cm.ConsumerAccount != "")
+	case PayPal:
+		v.required("consumerEmail", cm.ConsumerEmail != "")
+		v.required("paypalBillingAgreementId", cm.PaypalBillingAgreementID != "")
+	case "":
+		v.required("method", false)
+	default:
+		v.add("method", "must be %s or %s", DirectDebit, PayPal)
+	}
+
+	return v.err()
+}
+
+// Validate checks the amount of the refund when provided.
+func (cpr CreatePaymentRefund) Validate() error {
+	var v validation
+
+	v.amount("amount", cpr.Amount, false)
+
+	return v.err()
+}
+
+// Validate checks the amount of the capture when provided.
+func (cc CreateCapture) Validate() error {
+	var v validation
+
+	v.amount("amount", cc.Amount, false)
+
+	return v.err()
+}
+
+// Validate checks the recipient, the lines and the payment details of
+// the sales invoice.
+func (csi CreateSalesInvoice) Validate() error {
+	var v validation
+
+	switch csi.Status {
+	case "":
+		v.required("status", false)
+	case PaidSalesInvoiceStatus:
+		v.required("paymentDetails", csi.PaymentDetails != nil)
+	case DraftSalesInvoiceStatus, IssuedSalesInvoiceStatus:
+	default:
+		v.add("status", "must be %s, %s or %s", DraftSalesInvoiceStatus, IssuedSalesInvoiceStatus, PaidSalesInvoiceStatus)
+	}
+
+	v.required("vatScheme", csi.VATScheme != "")
+	v.required("vatMode", csi.VATMode != "")
+	v.required("recipientIdentifier", csi.RecipientIdentifier != "")
+	v.required("recipient.type", csi.Recipient.Type != "")
+	v.required("recipient.email", csi.Recipient.Email != "")
+	v.address("recipient", csi.Recipient.fields(), true)
+
+	if csi.Recipient.Type == BusinessSalesInvoiceRecipientType {
+		v.required("recipient.organizationName", csi.Recipient.OrganizationName != "")
+	}
+
+	v.required("lines", len(csi.Lines) > 0)
+
+	currency := ""
+
+	for i, l := range csi.Lines {
+		prefix := fmt.Sprintf("lines[%d].", i)
+		v.required(prefix+"description", l.Description != "")
+		v.required(prefix+"quantity", l.Quantity > 0)
+		v.required(prefix+"vatRate", l.VATRate != "")
+
+		if _, ok := v.amount(prefix+"unitPrice", &l.UnitPrice, true); !ok {
+			continue
+		}
+
+		if currency == "" {
+			currency = l.UnitPrice.Currency
+		} else if currency != l.UnitPrice.Currency {
+			v.add(prefix+"unitPrice", "must use the same currency as the other lines")
+		}
+
+		if l.Discount != nil {
+			if _, err := l.Total(); err != nil {
+				v.add(prefix+"discount", "is invalid")
+			}
+		}
+	}
+
+	return v.err()
+}
+
+// Validate checks the name, URL and event types of the webhook.
+func (cw CreateWebhook) Validate() error {
+	var v validation
+
+	v.required("name", cw.Name != "")
+	v.url("url", cw.URL, true)
+	v.required("eventTypes", len(cw.EventTypes) > 0)
+
+	return v.err()
+}
+
+// Validate checks the URL of the webhook when provided.
+func (uw UpdateWebhook) Validate() error {
+	var v validation
+
+	v.url("url", uw.URL, false)
+
+	return v.err()
+}
@@ -0,0 +1,268 @@
+package mollie
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func fieldNames(t *testing.T, err error) []string {
+	t.Helper()
+
+	if err == nil {
+		return nil
+	}
+
+	var ve *ValidationError
+	require.ErrorAs(t, err, &ve)
+
+	names := make([]string, len(ve.Fields))
+	for i, f := range ve.Fields {
+		names[i] = f.Field
+	}
+
+	return names
+}
+
+func eur(v string) *Amount {
+	return &Amount{Currency: "EUR", Value: v}
+}
+
+func TestCreatePayment_Validate(t *testing.T) {
+	cases := []struct {
+		name string
+		cp   CreatePayment
+		want []string
+	}{
+		{
+			"valid payment",
+			CreatePayment{Amount: eur("10.00"), Description: "Order", RedirectURL: "https://example.org/return"},
+			nil,
+		},
+		{
+			"missing required fields",
+			CreatePayment{},
+			[]string{"amount", "description", "redirectUrl"},
+		},
+		{
+			"amount with wrong decimals",
+			CreatePayment{Amount: eur("10.5"), Description: "Order", RedirectURL: "https://example.org"},
+			[]string{"amount"},
+		},
+		{
+			"recurring payments need a customer but no redirect",
+			CreatePayment{
+				Amount:                       eur("10.00"),
+				Description:                  "Order",
+				CreateRecurrentPaymentFields: CreateRecurrentPaymentFields{SequenceType: RecurringSequence},
+			},
+			[]string{"customerId"},
+		},
+		{
+			"method specific fields",
+			CreatePayment{
+				Amount:      eur("10.00"),
+				Description: "Order",
+				RedirectURL: "https://example.org",
+				Method:      []PaymentMethod{IDeal},
+				CardToken:   "tkn_123",
+				VoucherPin:  "1234",
+			},
+			[]string{"cardToken", "voucherNumber"},
+		},
+		{
+			"partial addresses",
+			CreatePayment{
+				Amount:         eur("10.00"),
+				Description:    "Order",
+				RedirectURL:    "https://example.org",
+				BillingAddress: &Address{City: "Amsterdam", Country: "nl"},
+			},
+			[]string{"billingAddress.streetAndNumber", "billingAddress.postalCode", "billingAddress.country"},
+		},
+		{
+			"invalid lines",
+			CreatePayment{
+				Amount:      eur("10.00"),
+				Description: "Order",
+				RedirectURL: "https://example.org",
+				Lines:       []PaymentLines{{Description: "LEGO", UnitPrice: eur("10.00")}},
+			},
+			[]string{"lines[0].quantity", "lines[0].totalAmount"},
+		},
+		{
+			"discount lines",
+			CreatePayment{
+				Amount:      eur("15.00"),
+				Description: "Order",
+				RedirectURL: "https://example.org",
+				Lines: []PaymentLines{
+					{Description: "LEGO", Quantity: 1, UnitPrice: eur("20.00"), TotalAmount: eur("20.00")},
+					{
+						Type:        DiscountProductLine,
+						Description: "Summer sale",
+						Quantity:    1,
+						UnitPrice:   eur("-5.00"),
+						TotalAmount: eur("-5.00"),
+						VATAmount:   eur("-0.87"),
+					},
+					{Description: "Refund", Quantity: 1, UnitPrice: eur("-5.00"), TotalAmount: eur("-5.00")},
+				},
+			},
+			[]string{"lines[2].unitPrice", "lines[2].totalAmount"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			assert.Equal(t, c.want, fieldNames(t, c.cp.Validate()))
+		})
+	}
+}
+
+func TestCreateOrder_Validate(t *testing.T) {
+	valid := func() CreateOrder {
+		return CreateOrder{
+			Amount:      eur("20.00"),
+			OrderNumber: "1337",
+			Locale:      Dutch,
+			RedirectURL: "https://example.org/return",
+			BillingAddress: &OrderAddress{
+				GivenName:       "Jane",
+				FamilyName:      "Doe",
+				Email:           "jane@example.org",
+				StreetAndNumber: "Keizersgracht 126",
+				PostalCode:      "1015 CW",
+				City:            "Amsterdam",
+				Country:         "NL",
+			},
+			Lines: []OrderLine{{
+				Name:        "LEGO",
+				Quantity:    2,
+				VatRate:     "21.00",
+				UnitPrice:   eur("10.00"),
+				TotalAmount: eur("20.00"),
+				VatAmount:   eur("3.47"),
+			}},
+		}
+	}
+
+	co := valid()
+	assert.Nil(t, co.Validate())
+
+	co.Amount = eur("19.00")
+	assert.Equal(t, []string{"amount"}, fieldNames(t, co.Validate()))
+
+	co = valid()
+	co.BillingAddress = nil
+	co.Lines[0].TotalAmount = &Amount{Currency: "USD", Value: "20.00"}
+	assert.Equal(t, []string{"billingAddress", "lines[0].totalAmount"}, fieldNames(t, co.Validate()))
+
+	co = valid()
+	co.Amount = eur("15.00")
+	co.Lines = append(co.Lines, OrderLine{
+		Name:        "Summer sale",
+		ProductType: DiscountProduct,
+		Quantity:    1,
+		VatRate:     "21.00",
+		UnitPrice:   eur("-5.00"),
+		TotalAmount: eur("-5.00"),
+		VatAmount:   eur("-0.87"),
+	})
+	assert.Nil(t, co.Validate(), "discount lines are deducted from the sum of the lines")
+
+	co.Amount = eur("-15.00")
+	assert.Equal(t, []string{"amount"}, fieldNames(t, co.Validate()))
+}
+
+func TestCreateSubscription_Validate(t *testing.T) {
+	cases := []struct {
+		interval string
+		want     []string
+	}{
+		{"1 month", nil},
+		{"14 days", nil},
+		{"2 weeks", nil},
+		{"monthly", []string{"interval"}},
+		{"0 days", []string{"interval"}},
+		{"", []string{"interval"}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.interval, func(t *testing.T) {
+			cs := CreateSubscription{Amount: eur("5.00"), Interval: c.interval, Description: "Plan"}
+			assert.Equal(t, c.want, fieldNames(t, cs.Validate()))
+		})
+	}
+
+	us := UpdateSubscription{Interval: "1 year"}
+	assert.Equal(t, []string{"interval"}, fieldNames(t, us.Validate()))
+}
+
+func TestCreateMandate_Validate(t *testing.T) {
+	assert.Nil(t, CreateMandate{Method: DirectDebit, ConsumerName: "Jane", ConsumerAccount: "NL55"}.Validate())
+	assert.Equal(t,
+		[]string{"consumerEmail", "paypalBillingAgreementId"},
+		fieldNames(t, CreateMandate{Method: PayPal, ConsumerName: "Jane"}.Validate()),
+	)
+	assert.Equal(t, []string{"consumerName", "method"}, fieldNames(t, CreateMandate{}.Validate()))
+}
+
+func TestCreateSalesInvoice_Validate(t *testing.T) {
+	csi := CreateSalesInvoice{
+		Status:              PaidSalesInvoiceStatus,
+		VATScheme:           StandardSalesInvoiceVATScheme,
+		VATMode:             ExclusiveSalesInvoiceVATMode,
+		RecipientIdentifier: "customer-xyz-0123",
+		Recipient: SalesInvoiceRecipient{
+			Type:  ConsumerSalesInvoiceRecipientType,
+			Email: "jane@example.org",
+			Address: Address{
+				StreetAndNumber: "Keizersgracht 126",
+				PostalCode:      "1015 CW",
+				City:            "Amsterdam",
+				Country:         "NL",
+			},
+		},
+		Lines: []SalesInvoiceLineItem{
+			{Description: "LEGO", Quantity: 1, VATRate: "21", UnitPrice: *eur("89.00")},
+			{Description: "Shipping", Quantity: 1, VATRate: "21", UnitPrice: Amount{Currency: "USD", Value: "5.00"}},
+		},
+	}
+
+	assert.Equal(t, []string{"paymentDetails", "lines[1].unitPrice"}, fieldNames(t, csi.Validate()))
+}
+
+func TestCreateWebhook_Validate(t *testing.T) {
+	assert.Equal(t, []string{"name", "url", "eventTypes"}, fieldNames(t, CreateWebhook{URL: "/relative"}.Validate()))
+	assert.Nil(t, UpdateWebhook{URL: "https://example.org/hook"}.Validate())
+}
+
+func TestClient_Validation(t *testing.T) {
+	setEnv()
+	setup()
+	defer teardown()
+	defer unsetEnv()
+
+	calls := 0
+	tMux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	})
+
+	_, _, err := tClient.Payments.Create(context.Background(), CreatePayment{}, nil)
+	assert.ErrorIs(t, err, ErrUnprocessable)
+	assert.Equal(t, 1, calls)
+
+	assert.True(t, tConf.ToggleValidation())
+
+	_, _, err = tClient.Payments.Create(context.Background(), CreatePayment{}, nil)
+	assert.ErrorIs(t, err, ErrUnprocessable)
+	assert.Equal(t, []string{"amount", "description", "redirectUrl"}, fieldNames(t, err))
+	assert.EqualError(t, err,
+		"validation failed: amount is required; description is required; redirectUrl is required")
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,162 @@
+package mollie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// ErrWebhookEntityMismatch is returned when registering a typed handler
+// whose entity does not match the entity embedded in the events of its type.
+var ErrWebhookEntityMismatch = errors.New("mollie: handler entity does not match the webhook event type")
+
+// webhookEntities maps the resource prefix of the event types, e.g.
+// sales-invoice for sales-invoice.paid, to the entity embedded in them.
+var webhookEntities = map[string]reflect.Type{
+	"payment-link":        reflect.TypeFor[PaymentLink](),
+	"sales-invoice":       reflect.TypeFor[SalesInvoice](),
+	"balance-transaction": reflect.TypeFor[BalanceTransaction](),
+}
+
+// webhookEntity returns the entity embedded in the events of type et.
+func webhookEntity(et WebhookEventType) (reflect.Type, bool) {
+	prefix, _, ok := strings.Cut(string(et), ".")
+	if !ok {
+		return nil, false
+	}
+
+	t, ok := webhookEntities[prefix]
+
+	return t, ok
+}
+
+// WebhookEventHandler handles a webhook event for which no typed entity is available.
+type WebhookEventHandler func(ctx context.Context, we *WebhookEvent) error
+
+// WebhookDispatcher routes webhook events to the handlers registered
+// for their WebhookEventType, decoding the embedded entity into the
+// type expected by each handler.
+//
+// Events without a registered handler are passed to the fallback handler,
+// if none is registered they are silently acknowledged.
+//
+// The Dispatch method can be passed directly to WebhookVerifier.Handler.
+type WebhookDispatcher struct {
+	mu       sync.RWMutex
+	handlers map[WebhookEventType]WebhookEventHandler
+	fallback WebhookEventHandler
+}
+
+// NewWebhookDispatcher returns an empty WebhookDispatcher.
+func NewWebhookDispatcher() *WebhookDispatcher {
+	return &WebhookDispatcher{
+		handlers: make(map[WebhookEventType]WebhookEventHandler),
+	}
+}
+
+// HandleWebhookEvent registers fn as the handler for the given event type,
+// the embedded entity is decoded into a *T before calling fn.
+//
+// When the event does not contain an embedded entity, fn receives a nil
+// entity and should fetch it using the event EntityID.
+//
+// ErrWebhookEntityMismatch is returned and fn is not registered when T is
+// not the entity embedded in the events of type et, e.g. a PaymentLink for
+// sales-invoice.paid, or when et has no known entity like AllWebhookEvents.
+func HandleWebhookEvent[T any](
+	d *WebhookDispatcher,
+	et WebhookEventType,
+	fn func(ctx context.Context, we *WebhookEvent, entity *T) error,
+) error {
+	want, ok := webhookEntity(et)
+	if got := reflect.TypeFor[T](); !ok || got != want {
+		return fmt.Errorf("%w: %s events do not embed a %s", ErrWebhookEntityMismatch, et, got.Name())
+	}
+
+	d.Handle(et, func(ctx context.Context, we *WebhookEvent) error {
+		if !we.HasEntity() {
+			return fn(ctx, we, nil)
+		}
+
+		entity := new(T)
+		if err := we.DecodeEntity(entity); err != nil {
+			return err
+		}
+
+		return fn(ctx, we, entity)
+	})
+
+	return nil
+}
+
+// Handle registers fn as the untyped handler for the given event type,
+// replacing any previous handler.
+func (d *WebhookDispatcher) Handle(et WebhookEventType, fn WebhookEventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.handlers[et] = fn
+}
+
+// Fallback registers the handler used for events without a registered handler.
+func (d *WebhookDispatcher) Fallback(fn WebhookEventHandler) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.fallback = fn
+}
+
+// OnPaymentLink registers a handler for payment-link.* events, see
+// HandleWebhookEvent.
+func (d *WebhookDispatcher) OnPaymentLink(
+	et WebhookEventType,
+	fn func(ctx context.Context, we *WebhookEvent, pl *PaymentLink) error,
+) error {
+	return HandleWebhookEvent(d, et, fn)
+}
+
+// OnSalesInvoice registers a handler for sales-invoice.* events, see
+// HandleWebhookEvent.
+func (d *WebhookDispatcher) OnSalesInvoice(
+	et WebhookEventType,
+	fn func(ctx context.Context, we *WebhookEvent, si *SalesInvoice) error,
+) error {
+	return HandleWebhookEvent(d, et, fn)
+}
+
+// OnBalanceTransaction registers a handler for balance-transaction.* events,
+// see HandleWebhookEvent.
+func (d *WebhookDispatcher) OnBalanceTransaction(
+	et WebhookEventType,
+	fn func(ctx context.Context, we *WebhookEvent, bt *BalanceTransaction) error,
+) error {
+	return HandleWebhookEvent(d, et, fn)
+}
+
+// Dispatch passes the event to the handler registered for its type.
+//
+// Handlers registered for AllWebhookEvents receive every event without
+// a more specific handler.
+func (d *WebhookDispatcher) Dispatch(ctx context.Context, we *WebhookEvent) error {
+	d.mu.RLock()
+
+	fn, ok := d.handlers[we.EventType()]
+	if !ok {
+		fn, ok = d.handlers[AllWebhookEvents]
+	}
+
+	if !ok {
+		fn = d.fallback
+	}
+
+	d.mu.RUnlock()
+
+	if fn == nil {
+		return nil
+	}
+
+	return fn(ctx, we)
+}
@@ -0,0 +1,180 @@
+package mollie
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const salesInvoicePaidEvent = `{
+	"resource": "event",
+	"id": "event_1",
+	"type": "sales-invoice.paid",
+	"entityId": "invoice_4Y0eZitmBnQ6IDoMqZQKh",
+	"_embedded": {
+		"entity": {
+			"resource": "sales-invoice",
+			"id": "invoice_4Y0eZitmBnQ6IDoMqZQKh",
+			"status": "paid",
+			"recipientIdentifier": "customer-xyz-0123",
+			"lines": [{"description": "LEGO", "quantity": 1, "vatRate": "21", "unitPrice": {"currency": "EUR", "value": "89.00"}}]
+		}
+	}
+}`
+
+const balanceTransactionEvent = `{
+	"resource": "event",
+	"id": "event_2",
+	"type": "balance-transaction.created",
+	"entityId": "baltr_QM24QwzUWR4ev4Xfgyt29A",
+	"_embedded": {
+		"entity": {
+			"resource": "balance_transaction",
+			"id": "baltr_QM24QwzUWR4ev4Xfgyt29A",
+			"type": "refund",
+			"resultAmount": {"currency": "EUR", "value": "-10.25"}
+		}
+	}
+}`
+
+func decodeEvent(t *testing.T, payload string) *WebhookEvent {
+	we := &WebhookEvent{}
+	require.Nil(t, json.Unmarshal([]byte(payload), we))
+
+	return we
+}
+
+func TestWebhookDispatcher_Dispatch(t *testing.T) {
+	d := NewWebhookDispatcher()
+
+	var got []string
+
+	err := d.OnPaymentLink(PaymentLinkPaidWebhookEvent, func(ctx context.Context, we *WebhookEvent, pl *PaymentLink) error {
+		got = append(got, "payment-link:"+pl.ID)
+
+		return nil
+	})
+	require.Nil(t, err)
+
+	err = d.OnSalesInvoice(SalesInvoicePaidWebhookEvent, func(ctx context.Context, we *WebhookEvent, si *SalesInvoice) error {
+		got = append(got, "sales-invoice:"+si.ID+":"+string(si.Status))
+
+		return nil
+	})
+	require.Nil(t, err)
+
+	err = d.OnBalanceTransaction(
+		BalanceTransactionCreatedWebhookEvent,
+		func(ctx context.Context, we *WebhookEvent, bt *BalanceTransaction) error {
+			got = append(got, "balance-transaction:"+bt.ID+":"+bt.ResultAmount.Value)
+
+			return nil
+		},
+	)
+	require.Nil(t, err)
+
+	d.Fallback(func(ctx context.Context, we *WebhookEvent) error {
+		got = append(got, "fallback:"+we.Type)
+
+		return nil
+	})
+
+	for _, p := range []string{
+		testdata.GetWebhookEventExample,
+		salesInvoicePaidEvent,
+		balanceTransactionEvent,
+		`{"id": "event_3", "type": "sales-invoice.created", "entityId": "invoice_1"}`,
+	} {
+		require.Nil(t, d.Dispatch(context.Background(), decodeEvent(t, p)))
+	}
+
+	assert.Equal(t, []string{
+		"payment-link:pl_4Y0eZitmBnQ6IDoMqZQKh",
+		"sales-invoice:invoice_4Y0eZitmBnQ6IDoMqZQKh:paid",
+		"balance-transaction:baltr_QM24QwzUWR4ev4Xfgyt29A:-10.25",
+		"fallback:sales-invoice.created",
+	}, got)
+}
+
+func TestWebhookDispatcher_DispatchWithoutEntity(t *testing.T) {
+	d := NewWebhookDispatcher()
+
+	called := false
+	err := d.OnSalesInvoice(SalesInvoiceIssuedWebhookEvent, func(ctx context.Context, we *WebhookEvent, si *SalesInvoice) error {
+		called = true
+
+		assert.Nil(t, si)
+		assert.Equal(t, "invoice_1", we.EntityID)
+
+		return nil
+	})
+	require.Nil(t, err)
+
+	err = d.Dispatch(context.Background(), decodeEvent(t, `{"type": "sales-invoice.issued", "entityId": "invoice_1"}`))
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
+
+func TestWebhookDispatcher_RejectsMismatchedEntities(t *testing.T) {
+	d := NewWebhookDispatcher()
+
+	err := d.OnPaymentLink(SalesInvoicePaidWebhookEvent, func(ctx context.Context, we *WebhookEvent, pl *PaymentLink) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrWebhookEntityMismatch)
+	assert.EqualError(t, err, ErrWebhookEntityMismatch.Error()+": sales-invoice.paid events do not embed a PaymentLink")
+
+	err = HandleWebhookEvent(d, AllWebhookEvents, func(ctx context.Context, we *WebhookEvent, si *SalesInvoice) error {
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrWebhookEntityMismatch)
+
+	err = HandleWebhookEvent(d, "sales-invoice.refunded", func(ctx context.Context, we *WebhookEvent, si *SalesInvoice) error {
+		return nil
+	})
+	assert.Nil(t, err)
+
+	d.Fallback(func(ctx context.Context, we *WebhookEvent) error {
+		return fmt.Errorf("fallback for %s", we.Type)
+	})
+
+	err = d.Dispatch(context.Background(), decodeEvent(t, salesInvoicePaidEvent))
+	assert.EqualError(t, err, "fallback for sales-invoice.paid")
+}
+
+func TestWebhookDispatcher_WildcardAndErrors(t *testing.T) {
+	d := NewWebhookDispatcher()
+
+	assert.Nil(t, d.Dispatch(context.Background(), decodeEvent(t, salesInvoicePaidEvent)))
+
+	d.Handle(AllWebhookEvents, func(ctx context.Context, we *WebhookEvent) error {
+		return fmt.Errorf("failed handling %s", we.ID)
+	})
+
+	err := d.Dispatch(context.Background(), decodeEvent(t, salesInvoicePaidEvent))
+	assert.EqualError(t, err, "failed handling event_1")
+}
+
+func TestWebhookEvent_DecodeEntity(t *testing.T) {
+	we := decodeEvent(t, salesInvoicePaidEvent)
+
+	assert.Equal(t, SalesInvoicePaidWebhookEvent, we.EventType())
+	assert.True(t, we.HasEntity())
+
+	si := &SalesInvoice{}
+	require.Nil(t, we.DecodeEntity(si))
+	assert.Equal(t, "customer-xyz-0123", si.RecipientIdentifier)
+
+	empty := decodeEvent(t, `{"type": "sales-invoice.issued"}`)
+	assert.False(t, empty.HasEntity())
+	assert.ErrorIs(t, empty.DecodeEntity(si), errNoWebhookEntity)
+
+	out, err := json.Marshal(we)
+	require.Nil(t, err)
+	assert.Contains(t, string(out), "customer-xyz-0123")
+}
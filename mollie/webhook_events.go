@@ -3,10 +3,13 @@ package mollie
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
 
+var errNoWebhookEntity = errors.New("webhook event has no embedded entity")
+
 // WebhookEventService handles webhook event API calls.
 type WebhookEventService service
 
@@ -67,8 +70,48 @@ type WebhookEventLinks struct {
 }
 
 // WebhookEventEmbedded represents the embedded entity object in a webhook event.
+//
+// Entity is shaped as a payment link, use WebhookEvent.DecodeEntity to
+// decode the entity of other event types.
 type WebhookEventEmbedded struct {
 	Entity WebhookEntity `json:"entity,omitempty"`
+	raw    json.RawMessage
+}
+
+// UnmarshalJSON keeps the raw entity around so it can be decoded
+// into the type matching the event.
+//
+// Entities that do not fit into WebhookEntity are left empty.
+func (e *WebhookEventEmbedded) UnmarshalJSON(b []byte) error {
+	var embedded struct {
+		Entity json.RawMessage `json:"entity,omitempty"`
+	}
+
+	if err := json.Unmarshal(b, &embedded); err != nil {
+		return err
+	}
+
+	e.raw = embedded.Entity
+	e.Entity = WebhookEntity{}
+
+	if len(e.raw) > 0 {
+		_ = json.Unmarshal(e.raw, &e.Entity)
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the raw entity when available.
+func (e WebhookEventEmbedded) MarshalJSON() ([]byte, error) {
+	if len(e.raw) > 0 {
+		return json.Marshal(struct {
+			Entity json.RawMessage `json:"entity"`
+		}{e.raw})
+	}
+
+	return json.Marshal(struct {
+		Entity WebhookEntity `json:"entity"`
+	}{e.Entity})
 }
 
 // WebhookEvent represents a webhook event received from Mollie.
@@ -82,6 +125,30 @@ type WebhookEvent struct {
 	CreatedAt *time.Time           `json:"createdAt,omitempty"`
 }
 
+// EventType returns the type of the event as a WebhookEventType.
+func (we *WebhookEvent) EventType() WebhookEventType {
+	return WebhookEventType(we.Type)
+}
+
+// HasEntity reports if the event contains an embedded snapshot of its entity.
+func (we *WebhookEvent) HasEntity() bool {
+	return len(we.Embedded.raw) > 0 && string(we.Embedded.raw) != "null"
+}
+
+// DecodeEntity decodes the embedded entity of the event into v,
+// e.g. a *SalesInvoice for sales-invoice.* events.
+func (we *WebhookEvent) DecodeEntity(v any) error {
+	if !we.HasEntity() {
+		return errNoWebhookEntity
+	}
+
+	if err := json.Unmarshal(we.Embedded.raw, v); err != nil {
+		return fmt.Errorf("webhook_entity_decoding_error: %w", err)
+	}
+
+	return nil
+}
+
 // Get retrieves a webhook event by its ID.
 //
 // See: https://docs.mollie.com/reference/get-webhook-event
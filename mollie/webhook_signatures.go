@@ -0,0 +1,268 @@
+package mollie
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Constants used when verifying next-gen webhook requests.
+const (
+	SignatureHeader = "X-Mollie-Signature"
+	SignaturePrefix = "sha256="
+	// DefaultWebhookReplayTTL is the minimum time processed events are
+	// remembered, it covers the redeliveries of failed webhook calls.
+	DefaultWebhookReplayTTL = 48 * time.Hour
+	maxWebhookPayloadSize   = 1 << 20
+)
+
+// Errors returned when a webhook request can not be trusted.
+var (
+	ErrMissingWebhookSignature = errors.New("webhook request has no signature header")
+	ErrInvalidWebhookSignature = errors.New("webhook signature does not match any secret")
+	ErrWebhookTimestamp        = errors.New("webhook event is outside the allowed time tolerance")
+	ErrWebhookReplayed         = errors.New("webhook event was already processed")
+	errNoWebhookSecrets        = errors.New("at least one webhook secret is required")
+)
+
+// WebhookReplayGuard keeps track of the webhook events already processed
+// to reject replayed payloads.
+//
+// Implementations must be safe for concurrent use.
+type WebhookReplayGuard interface {
+	// Seen reports if the event was already processed.
+	Seen(id string) bool
+	// Remember marks the event as processed for the given duration.
+	Remember(id string, ttl time.Duration)
+}
+
+// WebhookVerifier checks the signature of next-gen webhook requests sent by
+// Mollie and decodes their payload into a WebhookEvent.
+//
+// Multiple secrets can be provided to support secret rotation, a payload is
+// valid when its signature matches any of them.
+//
+// See: https://docs.mollie.com/guides/webhooks
+type WebhookVerifier struct {
+	secrets   [][]byte
+	tolerance time.Duration
+	guard     WebhookReplayGuard
+	now       func() time.Time
+}
+
+// NewWebhookVerifier returns a WebhookVerifier using the given webhook secrets
+// and an in-memory replay guard, the timestamp check is disabled.
+func NewWebhookVerifier(secrets ...string) (*WebhookVerifier, error) {
+	wv := &WebhookVerifier{
+		guard: NewMemoryReplayGuard(),
+		now:   time.Now,
+	}
+
+	for _, s := range secrets {
+		if s = strings.TrimSpace(s); s != "" {
+			wv.secrets = append(wv.secrets, []byte(s))
+		}
+	}
+
+	if len(wv.secrets) == 0 {
+		return nil, errNoWebhookSecrets
+	}
+
+	return wv, nil
+}
+
+// SetTolerance enables the timestamp check, events created longer than d
+// ago are rejected. A zero value disables the check, which is the default.
+//
+// Mollie redelivers failed webhook calls for hours using the original
+// event, so d must be longer than the time it keeps retrying or the
+// redeliveries are rejected.
+func (wv *WebhookVerifier) SetTolerance(d time.Duration) time.Duration {
+	wv.tolerance = d
+
+	return wv.tolerance
+}
+
+// SetReplayGuard changes the store used to detect replayed events,
+// passing nil disables the replay protection.
+func (wv *WebhookVerifier) SetReplayGuard(g WebhookReplayGuard) {
+	wv.guard = g
+}
+
+// Verify checks that signature is a valid signature of payload for any of
+// the configured secrets.
+func (wv *WebhookVerifier) Verify(payload []byte, signature string) error {
+	signature = strings.TrimSpace(signature)
+	if signature == "" {
+		return ErrMissingWebhookSignature
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(signature, SignaturePrefix))
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+
+	for _, secret := range wv.secrets {
+		mac := hmac.New(sha256.New, secret)
+		_, _ = mac.Write(payload)
+
+		if hmac.Equal(got, mac.Sum(nil)) {
+			return nil
+		}
+	}
+
+	return ErrInvalidWebhookSignature
+}
+
+// Parse reads the body of a webhook request, verifies its signature and
+// timestamp and decodes it into a WebhookEvent.
+//
+// Parse does not register the event with the replay guard, use Handler
+// or call Remember once the event was processed successfully.
+func (wv *WebhookVerifier) Parse(r *http.Request) (*WebhookEvent, error) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadSize))
+	if err != nil {
+		return nil, fmt.Errorf("webhook_read_error: %w", err)
+	}
+
+	return wv.ParsePayload(payload, r.Header.Get(SignatureHeader))
+}
+
+// ParsePayload verifies the raw payload and signature of a webhook and
+// decodes it into a WebhookEvent.
+func (wv *WebhookVerifier) ParsePayload(payload []byte, signature string) (*WebhookEvent, error) {
+	if err := wv.Verify(payload, signature); err != nil {
+		return nil, err
+	}
+
+	we := &WebhookEvent{}
+	if err := json.Unmarshal(payload, we); err != nil {
+		return nil, fmt.Errorf("webhook_decoding_error: %w", err)
+	}
+
+	if wv.tolerance > 0 {
+		if we.CreatedAt == nil || wv.now().Sub(*we.CreatedAt).Abs() > wv.tolerance {
+			return nil, ErrWebhookTimestamp
+		}
+	}
+
+	if wv.guard != nil && we.ID != "" && wv.guard.Seen(we.ID) {
+		return nil, ErrWebhookReplayed
+	}
+
+	return we, nil
+}
+
+// Remember registers the event as processed with the replay guard.
+func (wv *WebhookVerifier) Remember(we *WebhookEvent) {
+	if wv.guard == nil || we == nil || we.ID == "" {
+		return
+	}
+
+	wv.guard.Remember(we.ID, max(2*wv.tolerance, DefaultWebhookReplayTTL))
+}
+
+// Handler returns an http.Handler verifying every incoming webhook request
+// before passing the decoded event to fn.
+//
+// Requests with a missing or invalid signature are answered with
+// 401 Unauthorized, stale events with 409 Conflict and malformed payloads
+// with 400 Bad Request. Replayed events are acknowledged with 200 OK
+// without calling fn, so Mollie stops delivering them. When fn returns an
+// error the handler answers with 500 Internal Server Error so that Mollie
+// retries the delivery later.
+func (wv *WebhookVerifier) Handler(fn func(ctx context.Context, we *WebhookEvent) error) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		we, err := wv.Parse(r)
+		if errors.Is(err, ErrWebhookReplayed) {
+			w.WriteHeader(http.StatusOK)
+
+			return
+		}
+
+		if err != nil {
+			w.WriteHeader(webhookErrorStatus(err))
+
+			return
+		}
+
+		if err := fn(r.Context(), we); err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+
+			return
+		}
+
+		wv.Remember(we)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func webhookErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, ErrMissingWebhookSignature), errors.Is(err, ErrInvalidWebhookSignature):
+		return http.StatusUnauthorized
+	case errors.Is(err, ErrWebhookTimestamp):
+		return http.StatusConflict
+	default:
+		return http.StatusBadRequest
+	}
+}
+
+// memoryReplayGuard is an in-memory WebhookReplayGuard.
+type memoryReplayGuard struct {
+	mu     sync.Mutex
+	events map[string]time.Time
+	now    func() time.Time
+}
+
+// NewMemoryReplayGuard returns an in-memory WebhookReplayGuard, entries are
+// discarded once they expire.
+//
+// Use a shared implementation (e.g. backed by a database) when running
+// multiple instances of your webhook receiver.
+func NewMemoryReplayGuard() WebhookReplayGuard {
+	return &memoryReplayGuard{
+		events: make(map[string]time.Time),
+		now:    time.Now,
+	}
+}
+
+// Seen reports if the event was already processed.
+func (g *memoryReplayGuard) Seen(id string) bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	until, ok := g.events[id]
+
+	return ok && g.now().Before(until)
+}
+
+// Remember marks the event as processed for the given duration.
+func (g *memoryReplayGuard) Remember(id string, ttl time.Duration) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	for k, v := range g.events {
+		if !now.Before(v) {
+			delete(g.events, k)
+		}
+	}
+
+	g.events[id] = now.Add(ttl)
+}
@@ -0,0 +1,218 @@
+package mollie
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	_, _ = mac.Write([]byte(payload))
+
+	return SignaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+func newTestVerifier(t *testing.T, secrets ...string) *WebhookVerifier {
+	wv, err := NewWebhookVerifier(secrets...)
+	require.Nil(t, err)
+
+	wv.now = func() time.Time {
+		return time.Date(2024, 12, 16, 15, 58, 0, 0, time.UTC)
+	}
+
+	return wv
+}
+
+func TestNewWebhookVerifier_RequiresSecrets(t *testing.T) {
+	_, err := NewWebhookVerifier("", "  ")
+	assert.ErrorIs(t, err, errNoWebhookSecrets)
+}
+
+func TestWebhookVerifier_Verify(t *testing.T) {
+	payload := testdata.GetWebhookEventExample
+
+	cases := []struct {
+		name      string
+		secrets   []string
+		signature string
+		err       error
+	}{
+		{"valid signature", []string{"secret"}, sign("secret", payload), nil},
+		{"valid signature without prefix", []string{"secret"}, strings.TrimPrefix(sign("secret", payload), SignaturePrefix), nil},
+		{"rotated secrets are accepted", []string{"new", "old"}, sign("old", payload), nil},
+		{"missing signature", []string{"secret"}, "", ErrMissingWebhookSignature},
+		{"signature from another secret", []string{"secret"}, sign("other", payload), ErrInvalidWebhookSignature},
+		{"malformed signature", []string{"secret"}, "sha256=zz", ErrInvalidWebhookSignature},
+		{"tampered payload", []string{"secret"}, sign("secret", payload+" "), ErrInvalidWebhookSignature},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wv := newTestVerifier(t, c.secrets...)
+
+			err := wv.Verify([]byte(payload), c.signature)
+			if c.err != nil {
+				assert.ErrorIs(t, err, c.err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestWebhookVerifier_ParsePayload(t *testing.T) {
+	payload := testdata.GetWebhookEventExample
+
+	t.Run("valid events are decoded", func(t *testing.T) {
+		wv := newTestVerifier(t, "secret")
+
+		we, err := wv.ParsePayload([]byte(payload), sign("secret", payload))
+		require.Nil(t, err)
+		assert.Equal(t, "event_GvJ8WHrp5isUdRub9CJyH", we.ID)
+		assert.Equal(t, "pl_4Y0eZitmBnQ6IDoMqZQKh", we.Embedded.Entity.ID)
+	})
+
+	t.Run("redeliveries are accepted by default", func(t *testing.T) {
+		wv := newTestVerifier(t, "secret")
+		wv.now = func() time.Time { return time.Date(2024, 12, 17, 10, 0, 0, 0, time.UTC) }
+
+		_, err := wv.ParsePayload([]byte(payload), sign("secret", payload))
+		assert.Nil(t, err)
+	})
+
+	t.Run("stale events are rejected when a tolerance is set", func(t *testing.T) {
+		wv := newTestVerifier(t, "secret")
+		wv.now = func() time.Time { return time.Date(2024, 12, 17, 0, 0, 0, 0, time.UTC) }
+		wv.SetTolerance(time.Hour)
+
+		_, err := wv.ParsePayload([]byte(payload), sign("secret", payload))
+		assert.ErrorIs(t, err, ErrWebhookTimestamp)
+
+		wv.SetTolerance(0)
+
+		_, err = wv.ParsePayload([]byte(payload), sign("secret", payload))
+		assert.Nil(t, err)
+	})
+
+	t.Run("replayed events are rejected", func(t *testing.T) {
+		wv := newTestVerifier(t, "secret")
+
+		we, err := wv.ParsePayload([]byte(payload), sign("secret", payload))
+		require.Nil(t, err)
+
+		wv.Remember(we)
+
+		_, err = wv.ParsePayload([]byte(payload), sign("secret", payload))
+		assert.ErrorIs(t, err, ErrWebhookReplayed)
+
+		wv.SetReplayGuard(nil)
+
+		_, err = wv.ParsePayload([]byte(payload), sign("secret", payload))
+		assert.Nil(t, err)
+	})
+
+	t.Run("malformed payloads are rejected", func(t *testing.T) {
+		wv := newTestVerifier(t, "secret")
+
+		_, err := wv.ParsePayload([]byte("{hello"), sign("secret", "{hello"))
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "webhook_decoding_error")
+	})
+}
+
+func TestWebhookVerifier_Handler(t *testing.T) {
+	payload := testdata.GetWebhookEventExample
+
+	cases := []struct {
+		name      string
+		method    string
+		signature string
+		fnErr     error
+		want      int
+		called    bool
+	}{
+		{"valid request", http.MethodPost, sign("secret", payload), nil, http.StatusOK, true},
+		{"invalid method", http.MethodGet, sign("secret", payload), nil, http.StatusMethodNotAllowed, false},
+		{"invalid signature", http.MethodPost, sign("nope", payload), nil, http.StatusUnauthorized, false},
+		{"missing signature", http.MethodPost, "", nil, http.StatusUnauthorized, false},
+		{"callback failure", http.MethodPost, sign("secret", payload), fmt.Errorf("boom"), http.StatusInternalServerError, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			wv := newTestVerifier(t, "secret")
+
+			called := false
+			h := wv.Handler(func(ctx context.Context, we *WebhookEvent) error {
+				called = true
+
+				assert.Equal(t, "event_GvJ8WHrp5isUdRub9CJyH", we.ID)
+
+				return c.fnErr
+			})
+
+			req := httptest.NewRequest(c.method, "/webhooks", strings.NewReader(payload))
+			if c.signature != "" {
+				req.Header.Set(SignatureHeader, c.signature)
+			}
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			assert.Equal(t, c.want, rec.Code)
+			assert.Equal(t, c.called, called)
+		})
+	}
+}
+
+func TestWebhookVerifier_HandlerAcknowledgesReplays(t *testing.T) {
+	payload := testdata.GetWebhookEventExample
+	wv := newTestVerifier(t, "secret")
+
+	calls := 0
+	h := wv.Handler(func(ctx context.Context, we *WebhookEvent) error {
+		calls++
+
+		return nil
+	})
+
+	for _, want := range []int{http.StatusOK, http.StatusOK} {
+		req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(payload))
+		req.Header.Set(SignatureHeader, sign("secret", payload))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+
+		assert.Equal(t, want, rec.Code)
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestMemoryReplayGuard(t *testing.T) {
+	now := time.Now()
+	g := &memoryReplayGuard{events: map[string]time.Time{}, now: func() time.Time { return now }}
+
+	assert.False(t, g.Seen("event_1"))
+
+	g.Remember("event_1", time.Minute)
+	g.Remember("event_2", -time.Minute)
+
+	assert.True(t, g.Seen("event_1"))
+	assert.False(t, g.Seen("event_2"))
+
+	g.Remember("event_3", time.Minute)
+	assert.NotContains(t, g.events, "event_2")
+}
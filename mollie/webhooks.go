@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"iter"
 	"time"
 )
 
@@ -107,10 +108,6 @@ func (s *WebhookService) Create(ctx context.Context, wh CreateWebhook) (
 	w *Webhook,
 	err error,
 ) {
-	if s.client.HasAccessToken() && s.client.config.testing {
-		wh.TestMode = true
-	}
-
 	res, err = s.client.post(ctx, "/v2/webhooks", wh, nil)
 	if err != nil {
 		return
@@ -151,10 +148,6 @@ func (s *WebhookService) Update(ctx context.Context, webhook string, uw UpdateWe
 	w *Webhook,
 	err error,
 ) {
-	if s.client.HasAccessToken() && s.client.config.testing {
-		uw.TestMode = true
-	}
-
 	res, err = s.client.patch(ctx, fmt.Sprintf("/v2/webhooks/%s", webhook), uw)
 	if err != nil {
 		return
@@ -187,6 +180,13 @@ func (s *WebhookService) List(ctx context.Context, options *WebhooksListOptions)
 	return
 }
 
+// All returns an iterator over all the webhooks.
+func (s *WebhookService) All(ctx context.Context, options *WebhooksListOptions) iter.Seq2[*Webhook, error] {
+	return paginate(ctx, s.client, "v2/webhooks", options, func(l *WebhookList) ([]*Webhook, *URL) {
+		return l.Embedded.Webhooks, l.Links.Next
+	})
+}
+
 // Delete removes a webhook by its ID.
 //
 // See: https://docs.mollie.com/reference/delete-webhook
@@ -195,11 +195,6 @@ func (s *WebhookService) Delete(ctx context.Context, webhook string) (
 	err error,
 ) {
 	var dw DeleteWebhook
-	if s.client.HasAccessToken() && s.client.config.testing {
-		dw = DeleteWebhook{
-			TestMode: true,
-		}
-	}
 
 	res, err = s.client.delete(ctx, fmt.Sprintf("/v2/webhooks/%s", webhook), dw)
 	if err != nil {
@@ -217,11 +212,6 @@ func (s *WebhookService) Test(ctx context.Context, webhook string) (
 	err error,
 ) {
 	var tw TestWebhook
-	if s.client.HasAccessToken() && s.client.config.testing {
-		tw = TestWebhook{
-			TestMode: true,
-		}
-	}
 
 	res, err = s.client.post(ctx, fmt.Sprintf("/v2/webhooks/%s/ping", webhook), tw, nil)
 	if err != nil {
@@ -0,0 +1,307 @@
+package bulk
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/google/uuid"
+)
+
+// DefaultConcurrency is the number of items processed at the same time
+// when the Executor does not configure a concurrency.
+const DefaultConcurrency = 4
+
+// errDuplicateID is recorded for the items sharing the ID of a previous item.
+var errDuplicateID = errors.New("duplicate item id")
+
+// Status describes the outcome of an item.
+type Status string
+
+// Possible item statuses.
+const (
+	// StatusSucceeded is used for the items whose operation succeeded, in
+	// this run or in the resumed one.
+	StatusSucceeded Status = "succeeded"
+	// StatusFailed is used for the items whose operation returned an error.
+	StatusFailed Status = "failed"
+	// StatusSkipped is used for the items that were not attempted, because
+	// the context was done or their ID was already used.
+	StatusSkipped Status = "skipped"
+)
+
+// Item is an input of the operation identified by an ID, which must be
+// stable across runs, e.g. the ID of the subscription to cancel.
+type Item[T any] struct {
+	ID    string
+	Input T
+}
+
+// Func performs the operation for a single input, ctx carries the
+// idempotency key of the item and must be passed to the client.
+type Func[T any] func(ctx context.Context, in T) error
+
+// Result is the outcome of a single item.
+type Result struct {
+	ID             string `json:"id"`
+	Status         Status `json:"status"`
+	IdempotencyKey string `json:"idempotencyKey,omitempty"`
+	// HTTPStatus is the status code of the API error, if any.
+	HTTPStatus int    `json:"httpStatus,omitempty"`
+	Error      string `json:"error,omitempty"`
+	// Resumed is true when the result was taken from the resumed report.
+	Resumed bool `json:"resumed,omitempty"`
+	// Err is the error returned by the operation, it is not persisted.
+	Err error `json:"-"`
+}
+
+// Report contains the results of a run, in the order of the items.
+type Report struct {
+	Results []*Result `json:"results"`
+}
+
+// Result returns the result of the item with the given ID, or nil.
+func (r *Report) Result(id string) *Result {
+	for _, res := range r.Results {
+		if res.ID == id {
+			return res
+		}
+	}
+
+	return nil
+}
+
+// Count returns the number of results with the given status.
+func (r *Report) Count(s Status) int {
+	n := 0
+
+	for _, res := range r.Results {
+		if res.Status == s {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Failed returns the results of the items whose operation failed.
+func (r *Report) Failed() []*Result {
+	var failed []*Result
+
+	for _, res := range r.Results {
+		if res.Status == StatusFailed {
+			failed = append(failed, res)
+		}
+	}
+
+	return failed
+}
+
+// LoadReport reads a report from a journal written by an Executor, when an
+// item appears more than once its last result is kept.
+func LoadReport(r io.Reader) (*Report, error) {
+	report := new(Report)
+	index := make(map[string]int)
+
+	sc := bufio.NewScanner(r)
+	for sc.Scan() {
+		if len(sc.Bytes()) == 0 {
+			continue
+		}
+
+		res := new(Result)
+		if err := json.Unmarshal(sc.Bytes(), res); err != nil {
+			return nil, fmt.Errorf("bulk: decoding journal: %w", err)
+		}
+
+		if i, ok := index[res.ID]; ok {
+			report.Results[i] = res
+
+			continue
+		}
+
+		index[res.ID] = len(report.Results)
+		report.Results = append(report.Results, res)
+	}
+
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("bulk: reading journal: %w", err)
+	}
+
+	return report, nil
+}
+
+// Executor runs an operation over a list of items.
+type Executor[T any] struct {
+	// Operation names the operation, it is part of the idempotency keys so
+	// different operations on the same items use different keys.
+	Operation string
+	// Concurrency is the maximum number of items processed at the same time,
+	// DefaultConcurrency is used when lower than 1.
+	Concurrency int
+	// Journal receives every result as a JSON line as soon as it is known.
+	Journal io.Writer
+	// Resume contains the report of a previous run, its succeeded items are
+	// not attempted again.
+	Resume *Report
+
+	mu sync.Mutex
+}
+
+// IdempotencyKey returns the idempotency key sent for the item with the
+// given ID, the same operation and ID always produce the same key.
+func (e *Executor[T]) IdempotencyKey(id string) string {
+	return uuid.NewSHA1(uuid.NameSpaceURL, []byte("mollie-bulk:"+e.Operation+":"+id)).String()
+}
+
+// Run calls fn for every item and returns the report of the run, the
+// error is only set when writing to the journal failed.
+//
+// Once ctx is done no more items are started and the remaining ones are
+// reported as skipped.
+func (e *Executor[T]) Run(ctx context.Context, items []Item[T], fn Func[T]) (*Report, error) {
+	report := &Report{Results: make([]*Result, len(items))}
+	seen := make(map[string]bool, len(items))
+	pending := make(chan int)
+
+	succeeded := make(map[string]*Result)
+	if e.Resume != nil {
+		for _, res := range e.Resume.Results {
+			if res.Status == StatusSucceeded {
+				succeeded[res.ID] = res
+			}
+		}
+	}
+
+	var (
+		wg      sync.WaitGroup
+		journal error
+	)
+
+	record := func(i int, res *Result) {
+		report.Results[i] = res
+		if err := e.write(res); err != nil && journal == nil {
+			journal = err
+		}
+	}
+
+	workers := e.Concurrency
+	if workers < 1 {
+		workers = DefaultConcurrency
+	}
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for i := range pending {
+				res := e.run(ctx, items[i], fn)
+
+				e.mu.Lock()
+				record(i, res)
+				e.mu.Unlock()
+			}
+		}()
+	}
+
+	for i, item := range items {
+		res := e.precheck(ctx, item, seen, succeeded)
+		if res == nil {
+			select {
+			case pending <- i:
+				continue
+			case <-ctx.Done():
+				res = e.skipped(item.ID, ctx.Err())
+			}
+		}
+
+		e.mu.Lock()
+		record(i, res)
+		e.mu.Unlock()
+	}
+
+	close(pending)
+	wg.Wait()
+
+	return report, journal
+}
+
+// precheck returns the result of an item that must not be attempted.
+func (e *Executor[T]) precheck(
+	ctx context.Context,
+	item Item[T],
+	seen map[string]bool,
+	succeeded map[string]*Result,
+) *Result {
+	if seen[item.ID] {
+		return e.skipped(item.ID, errDuplicateID)
+	}
+
+	seen[item.ID] = true
+
+	if prev, ok := succeeded[item.ID]; ok {
+		res := *prev
+		res.Resumed = true
+
+		return &res
+	}
+
+	if err := ctx.Err(); err != nil {
+		return e.skipped(item.ID, err)
+	}
+
+	return nil
+}
+
+func (e *Executor[T]) run(ctx context.Context, item Item[T], fn Func[T]) *Result {
+	res := &Result{ID: item.ID, IdempotencyKey: e.IdempotencyKey(item.ID)}
+
+	if err := ctx.Err(); err != nil {
+		return e.skipped(item.ID, err)
+	}
+
+	err := fn(mollie.WithIdempotencyKey(ctx, res.IdempotencyKey), item.Input)
+	if err == nil {
+		res.Status = StatusSucceeded
+
+		return res
+	}
+
+	res.Status, res.Err, res.Error = StatusFailed, err, err.Error()
+
+	var be *mollie.BaseError
+	if errors.As(err, &be) {
+		res.HTTPStatus = be.Status
+	}
+
+	return res
+}
+
+func (e *Executor[T]) skipped(id string, err error) *Result {
+	return &Result{ID: id, Status: StatusSkipped, Error: err.Error(), Err: err}
+}
+
+// write appends a result to the journal, e.mu must be held.
+func (e *Executor[T]) write(res *Result) error {
+	if e.Journal == nil {
+		return nil
+	}
+
+	b, err := json.Marshal(res)
+	if err != nil {
+		return fmt.Errorf("bulk: encoding result: %w", err)
+	}
+
+	if _, err := e.Journal.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("bulk: writing journal: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,234 @@
+package bulk
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type subscription struct {
+	customer string
+	id       string
+}
+
+// subscriptionsAPI answers subscription cancellations, failing the
+// subscriptions listed in fail.
+type subscriptionsAPI struct {
+	mu       sync.Mutex
+	fail     map[string]int
+	keys     map[string][]string
+	active   atomic.Int32
+	maxLoad  atomic.Int32
+	requests atomic.Int32
+}
+
+func (api *subscriptionsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	api.requests.Add(1)
+
+	n := api.active.Add(1)
+	defer api.active.Add(-1)
+
+	for {
+		m := api.maxLoad.Load()
+		if n <= m || api.maxLoad.CompareAndSwap(m, n) {
+			break
+		}
+	}
+
+	time.Sleep(5 * time.Millisecond)
+
+	id := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+	api.mu.Lock()
+	api.keys[id] = append(api.keys[id], r.Header.Get(mollie.IdempotencyKeyHeader))
+	status := api.fail[id]
+	api.mu.Unlock()
+
+	if status != 0 {
+		w.WriteHeader(status)
+		_, _ = fmt.Fprintf(w, `{"status":%d,"title":"Failure","detail":"Cannot cancel %s"}`, status, id)
+
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, `{"resource":"subscription","id":"%s","status":"canceled"}`, id)
+}
+
+func newSubscriptionsAPI(t *testing.T, fail map[string]int) (*subscriptionsAPI, *mollie.Client) {
+	t.Helper()
+
+	api := &subscriptionsAPI{fail: fail, keys: make(map[string][]string)}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	t.Setenv(mollie.APITokenEnv, "test_dHar4XY7LxsDOtmnkVtjNVWXLSlXsM")
+
+	c, err := mollie.NewClient(nil, mollie.NewAPITestingConfig(false))
+	require.Nil(t, err)
+
+	c.BaseURL, err = url.Parse(srv.URL + "/")
+	require.Nil(t, err)
+
+	return api, c
+}
+
+func subscriptions(n int) []Item[subscription] {
+	items := make([]Item[subscription], n)
+	for i := range items {
+		id := fmt.Sprintf("sub_%02d", i)
+		items[i] = Item[subscription]{ID: id, Input: subscription{customer: "cst_8wmqcHMN4U", id: id}}
+	}
+
+	return items
+}
+
+func cancel(c *mollie.Client) Func[subscription] {
+	return func(ctx context.Context, s subscription) error {
+		_, _, err := c.Subscriptions.Cancel(ctx, s.customer, s.id)
+
+		return err
+	}
+}
+
+func TestExecutor_Run(t *testing.T) {
+	api, client := newSubscriptionsAPI(t, map[string]int{"sub_03": http.StatusUnprocessableEntity})
+
+	items := append(subscriptions(20), Item[subscription]{ID: "sub_05"})
+	e := &Executor[subscription]{Operation: "cancel-subscriptions", Concurrency: 3}
+
+	report, err := e.Run(context.Background(), items, cancel(client))
+	require.Nil(t, err)
+	require.Len(t, report.Results, 21)
+
+	assert.Equal(t, 19, report.Count(StatusSucceeded))
+	assert.Equal(t, 1, report.Count(StatusSkipped), "duplicated ids are skipped")
+	assert.EqualValues(t, 20, api.requests.Load())
+	assert.LessOrEqual(t, api.maxLoad.Load(), int32(3))
+
+	failed := report.Failed()
+	require.Len(t, failed, 1)
+	assert.Equal(t, "sub_03", failed[0].ID)
+	assert.Equal(t, http.StatusUnprocessableEntity, failed[0].HTTPStatus)
+	assert.ErrorIs(t, failed[0].Err, mollie.ErrUnprocessable)
+
+	for i, res := range report.Results[:20] {
+		assert.Equal(t, items[i].ID, res.ID, "results are in the order of the items")
+		assert.Equal(t, []string{e.IdempotencyKey(res.ID)}, api.keys[res.ID])
+	}
+}
+
+func TestExecutor_IdempotencyKey(t *testing.T) {
+	cancels := &Executor[subscription]{Operation: "cancel-subscriptions"}
+	refunds := &Executor[subscription]{Operation: "refund-payments"}
+
+	assert.Equal(t, cancels.IdempotencyKey("sub_01"), cancels.IdempotencyKey("sub_01"))
+	assert.NotEqual(t, cancels.IdempotencyKey("sub_01"), cancels.IdempotencyKey("sub_02"))
+	assert.NotEqual(t, cancels.IdempotencyKey("sub_01"), refunds.IdempotencyKey("sub_01"))
+}
+
+func TestExecutor_Resume(t *testing.T) {
+	api, client := newSubscriptionsAPI(t, map[string]int{
+		"sub_02": http.StatusServiceUnavailable,
+		"sub_07": http.StatusServiceUnavailable,
+	})
+
+	journal := new(bytes.Buffer)
+	items := subscriptions(10)
+	e := &Executor[subscription]{Operation: "cancel-subscriptions", Journal: journal}
+
+	report, err := e.Run(context.Background(), items, cancel(client))
+	require.Nil(t, err)
+	require.Equal(t, 2, report.Count(StatusFailed))
+
+	// the process crashes, the report is restored from the journal.
+	previous, err := LoadReport(bytes.NewReader(journal.Bytes()))
+	require.Nil(t, err)
+	require.Len(t, previous.Results, 10)
+	assert.Equal(t, StatusFailed, previous.Result("sub_07").Status)
+
+	api.mu.Lock()
+	api.fail = nil
+	api.mu.Unlock()
+
+	e = &Executor[subscription]{Operation: "cancel-subscriptions", Resume: previous}
+
+	report, err = e.Run(context.Background(), items, cancel(client))
+	require.Nil(t, err)
+	assert.Equal(t, 10, report.Count(StatusSucceeded))
+	assert.EqualValues(t, 12, api.requests.Load(), "only the failed items are attempted again")
+	assert.True(t, report.Result("sub_01").Resumed)
+	assert.False(t, report.Result("sub_02").Resumed)
+	assert.Equal(t, api.keys["sub_02"][0], api.keys["sub_02"][1], "reruns reuse the idempotency key")
+}
+
+func TestExecutor_RunCanceled(t *testing.T) {
+	api, client := newSubscriptionsAPI(t, nil)
+
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+
+	var calls atomic.Int32
+
+	fn := func(ctx context.Context, s subscription) error {
+		if calls.Add(1) == 2 {
+			stop()
+		}
+
+		return cancel(client)(ctx, s)
+	}
+
+	e := &Executor[subscription]{Operation: "cancel-subscriptions", Concurrency: 1}
+
+	report, err := e.Run(ctx, subscriptions(10), fn)
+	require.Nil(t, err)
+	assert.Equal(t, 1, report.Count(StatusSucceeded))
+	assert.Equal(t, 1, report.Count(StatusFailed))
+	assert.Equal(t, 8, report.Count(StatusSkipped))
+	assert.LessOrEqual(t, api.requests.Load(), int32(1))
+
+	for _, res := range report.Results[2:] {
+		assert.ErrorIs(t, res.Err, context.Canceled)
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) { return 0, errors.New("disk full") }
+
+func TestExecutor_JournalError(t *testing.T) {
+	e := &Executor[int]{Operation: "noop", Journal: failingWriter{}}
+
+	report, err := e.Run(context.Background(), []Item[int]{{ID: "1"}}, func(context.Context, int) error { return nil })
+	assert.ErrorContains(t, err, "disk full")
+	assert.Equal(t, 1, report.Count(StatusSucceeded))
+}
+
+func TestLoadReport(t *testing.T) {
+	journal := `{"id":"a","status":"failed","error":"boom"}
+
+{"id":"b","status":"succeeded"}
+{"id":"a","status":"succeeded"}
+`
+
+	report, err := LoadReport(strings.NewReader(journal))
+	require.Nil(t, err)
+	require.Len(t, report.Results, 2)
+	assert.Equal(t, StatusSucceeded, report.Result("a").Status)
+	assert.Nil(t, report.Result("c"))
+
+	_, err = LoadReport(strings.NewReader("{"))
+	assert.NotNil(t, err)
+}
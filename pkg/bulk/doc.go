@@ -0,0 +1,14 @@
+// Package bulk runs the same Mollie API operation over many items, e.g.
+// canceling subscriptions, refunding payments or creating payment links.
+//
+// The Executor calls the operation with bounded concurrency, every item is
+// sent with an idempotency key derived from the operation name and the item
+// ID, so running the same batch again never performs an operation twice
+// while Mollie remembers the key. The requests go through the client, so
+// its rate limiter, retry policy and circuit breaker apply.
+//
+// The outcome of every item is collected in a Report. When a Journal is
+// configured each result is also appended to it as soon as it is known,
+// after a crash the journal is loaded with LoadReport and passed as Resume
+// to skip the items that already succeeded.
+package bulk
@@ -0,0 +1,297 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+)
+
+// ErrNoInteraction is returned when replaying a request that is not
+// recorded in the cassette.
+var ErrNoInteraction = errors.New("cassette: no recorded interaction matches the request")
+
+// Mode describes if a Recorder performs real requests or replays them.
+type Mode int
+
+// Recorder modes.
+const (
+	// ModeReplay replays the interactions of an existing cassette and never
+	// performs real requests.
+	ModeReplay Mode = iota
+	// ModeRecord performs real requests and overwrites the cassette when the
+	// Recorder is stopped.
+	ModeRecord
+	// ModeAuto replays the cassette when the file exists and records it
+	// otherwise.
+	ModeAuto
+)
+
+// Request is the recorded part of an HTTP request.
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Response is the recorded part of an HTTP response.
+type Response struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Interaction is a request and the response returned for it.
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper recording or replaying interactions.
+//
+// Transport and Scrubbers can be changed after calling New and before
+// performing the first request.
+type Recorder struct {
+	// Transport performs the real requests when recording,
+	// http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+	// Scrubbers are applied to every interaction before it is stored and
+	// to every request before it is matched, DefaultScrubbers by default.
+	Scrubbers []Scrubber
+
+	path string
+	mode Mode
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+// New returns a Recorder storing its interactions in the cassette file at
+// path. When replaying, the cassette is read immediately.
+func New(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		Scrubbers: DefaultScrubbers(),
+		path:      path,
+		mode:      mode,
+	}
+
+	if mode == ModeAuto {
+		r.mode = ModeRecord
+		if _, err := os.Stat(path); err == nil {
+			r.mode = ModeReplay
+		}
+	}
+
+	if r.mode != ModeReplay {
+		return r, nil
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(b, &r.cassette); err != nil {
+		return nil, fmt.Errorf("cassette: decoding %s: %w", path, err)
+	}
+
+	r.replayed = make([]bool, len(r.cassette.Interactions))
+
+	return r, nil
+}
+
+// Mode returns the mode of the recorder, ModeAuto is resolved to either
+// ModeReplay or ModeRecord.
+func (r *Recorder) Mode() Mode {
+	return r.mode
+}
+
+// Client returns an http.Client using the recorder as transport, to be
+// passed to mollie.NewClient.
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r}
+}
+
+// Stop writes the recorded interactions to the cassette file, it does
+// nothing when replaying.
+func (r *Recorder) Stop() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return fmt.Errorf("cassette: encoding %s: %w", r.path, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+
+	//nolint: gosec
+	if err := os.WriteFile(r.path, append(b, '\n'), 0o644); err != nil {
+		return fmt.Errorf("cassette: %w", err)
+	}
+
+	return nil
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readBody(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading request body: %w", err)
+	}
+
+	in := &Interaction{Request: Request{
+		Method: req.Method,
+		URL:    req.URL.String(),
+		Header: req.Header.Clone(),
+		Body:   string(body),
+	}}
+
+	if r.mode == ModeReplay {
+		return r.replay(req, in)
+	}
+
+	out := req.Clone(req.Context())
+	if body != nil {
+		out.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	return r.record(out, in)
+}
+
+func (r *Recorder) record(req *http.Request, in *Interaction) (*http.Response, error) {
+	t := r.Transport
+	if t == nil {
+		t = http.DefaultTransport
+	}
+
+	res, err := t.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := readBody(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("cassette: reading response body: %w", err)
+	}
+
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	in.Response = Response{
+		Status: res.StatusCode,
+		Header: res.Header.Clone(),
+		Body:   string(body),
+	}
+	r.scrub(in)
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+
+	return res, nil
+}
+
+func (r *Recorder) replay(req *http.Request, in *Interaction) (*http.Response, error) {
+	r.scrub(in)
+
+	key, err := matchKey(&in.Request)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// interactions are replayed in the recorded order, so the same request
+	// can return different responses over the course of a flow.
+	for i, rec := range r.cassette.Interactions {
+		if r.replayed[i] {
+			continue
+		}
+
+		k, err := matchKey(&rec.Request)
+		if err != nil || k != key {
+			continue
+		}
+
+		r.replayed[i] = true
+
+		return &http.Response{
+			Status:        strconv.Itoa(rec.Response.Status) + " " + http.StatusText(rec.Response.Status),
+			StatusCode:    rec.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        rec.Response.Header.Clone(),
+			Body:          io.NopCloser(bytes.NewBufferString(rec.Response.Body)),
+			ContentLength: int64(len(rec.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrNoInteraction, in.Request.Method, in.Request.URL)
+}
+
+func (r *Recorder) scrub(in *Interaction) {
+	for _, s := range r.Scrubbers {
+		s(in)
+	}
+}
+
+// matchKey returns the method, path, sorted query string and normalized
+// body of a request.
+func matchKey(req *Request) (string, error) {
+	u, err := url.Parse(req.URL)
+	if err != nil {
+		return "", fmt.Errorf("cassette: parsing url: %w", err)
+	}
+
+	return fmt.Sprintf("%s %s?%s\n%s", req.Method, u.Path, u.Query().Encode(), normalizeBody(req.Body)), nil
+}
+
+// normalizeBody re-encodes JSON bodies so the order of the keys and the
+// whitespace do not affect matching.
+func normalizeBody(body string) string {
+	var v any
+	if json.Unmarshal([]byte(body), &v) != nil {
+		return body
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return body
+	}
+
+	return string(b)
+}
+
+// readBody reads and closes a request or response body.
+func readBody(rc io.ReadCloser) ([]byte, error) {
+	if rc == nil || rc == http.NoBody {
+		return nil, nil
+	}
+
+	defer func() { _ = rc.Close() }()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,24 @@
+// Package cassette contains an http.RoundTripper recording the requests
+// made by the Mollie API client to a file, a cassette, and replaying them
+// later without network access.
+//
+// A flow is recorded once against the Mollie sandbox and the resulting
+// cassette is committed next to the tests, which then run offline and
+// deterministically in CI:
+//
+//	rec, err := cassette.New("testdata/refund_flow.json", cassette.ModeAuto)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Stop()
+//
+//	client, err := mollie.NewClient(rec.Client(), mollie.NewAPITestingConfig(false))
+//
+// Before an interaction is stored, and before a request is matched against
+// the stored interactions, the Scrubbers of the Recorder remove the
+// Authorization header, API keys, OAuth tokens and personal data, so the
+// cassettes can be shared safely.
+//
+// Requests are matched on their method, path, query string and normalized
+// body, the host is ignored.
+package cassette
@@ -0,0 +1,148 @@
+package cassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// Redacted replaces the scrubbed values.
+const Redacted = "REDACTED"
+
+// Scrubber removes sensitive data from an interaction in place.
+type Scrubber func(in *Interaction)
+
+// SensitiveHeaders are removed from the recorded requests and responses
+// by ScrubHeaders.
+var SensitiveHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+}
+
+// PersonalDataFields are the JSON fields redacted by ScrubPersonalData,
+// the fields never written to the logs by the client.
+var PersonalDataFields = mollie.SensitiveFields()
+
+// tokenPattern matches API keys, organization access tokens, OAuth tokens,
+// authorization codes and card tokens.
+var tokenPattern = regexp.MustCompile(`\b(live|test|access|refresh|auth|tkn)_[A-Za-z0-9]{10,}`)
+
+// secretParamPattern matches secrets sent in form encoded bodies.
+var secretParamPattern = regexp.MustCompile(`\b(client_secret|code|refresh_token|token)=[^&\s]+`)
+
+// DefaultScrubbers returns the scrubbers used by a new Recorder.
+func DefaultScrubbers() []Scrubber {
+	return []Scrubber{
+		ScrubHeaders,
+		ScrubTokens,
+		ScrubPersonalData,
+	}
+}
+
+// ScrubHeaders removes the SensitiveHeaders.
+func ScrubHeaders(in *Interaction) {
+	for _, h := range SensitiveHeaders {
+		in.Request.Header.Del(h)
+		in.Response.Header.Del(h)
+	}
+}
+
+// ScrubTokens replaces the secret of API keys, access tokens, OAuth tokens,
+// authorization codes and card tokens found in the url, headers and bodies, keeping
+// their prefix.
+func ScrubTokens(in *Interaction) {
+	replace := func(s string) string {
+		s = tokenPattern.ReplaceAllString(s, "${1}_"+Redacted)
+
+		return secretParamPattern.ReplaceAllString(s, "${1}="+Redacted)
+	}
+
+	in.Request.URL = tokenPattern.ReplaceAllString(in.Request.URL, "${1}_"+Redacted)
+	in.Request.Body = replace(in.Request.Body)
+	in.Response.Body = replace(in.Response.Body)
+
+	for _, h := range []http.Header{in.Request.Header, in.Response.Header} {
+		for k, vs := range h {
+			for i := range vs {
+				vs[i] = replace(vs[i])
+			}
+
+			h[k] = vs
+		}
+	}
+}
+
+// ScrubPersonalData redacts the PersonalDataFields of JSON bodies.
+func ScrubPersonalData(in *Interaction) {
+	ScrubJSONFields(PersonalDataFields...)(in)
+}
+
+// ScrubJSONFields returns a Scrubber redacting the string values of the
+// given fields, at any depth, in JSON request and response bodies. Field
+// names are matched case insensitively.
+func ScrubJSONFields(fields ...string) Scrubber {
+	return func(in *Interaction) {
+		in.Request.Body = redactJSON(in.Request.Body, fields)
+		in.Response.Body = redactJSON(in.Response.Body, fields)
+	}
+}
+
+func redactJSON(body string, fields []string) string {
+	var v any
+	if !strings.ContainsAny(body, "{[") || json.Unmarshal([]byte(body), &v) != nil {
+		return body
+	}
+
+	if !redact(v, fields) {
+		return body
+	}
+
+	buf := new(bytes.Buffer)
+	enc := json.NewEncoder(buf)
+	enc.SetEscapeHTML(false)
+
+	if err := enc.Encode(v); err != nil {
+		return body
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+// redact replaces the string values of fields in v and reports if any
+// value was replaced.
+func redact(v any, fields []string) bool {
+	changed := false
+
+	switch v := v.(type) {
+	case map[string]any:
+		for k, val := range v {
+			if s, ok := val.(string); ok && s != "" && containsFold(fields, k) {
+				v[k] = Redacted
+				changed = true
+
+				continue
+			}
+
+			changed = redact(val, fields) || changed
+		}
+	case []any:
+		for _, val := range v {
+			changed = redact(val, fields) || changed
+		}
+	}
+
+	return changed
+}
+
+func containsFold(fields []string, field string) bool {
+	return slices.ContainsFunc(fields, func(f string) bool {
+		return strings.EqualFold(f, field)
+	})
+}
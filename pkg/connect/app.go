@@ -0,0 +1,186 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"golang.org/x/oauth2"
+)
+
+// TokenTypeHint tells Mollie which kind of token is revoked.
+type TokenTypeHint string
+
+// Supported token type hints.
+const (
+	AccessTokenHint  TokenTypeHint = "access_token"
+	RefreshTokenHint TokenTypeHint = "refresh_token"
+)
+
+// App describes a Mollie Connect app, as registered in the Mollie dashboard.
+type App struct {
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is the URL Mollie redirects the merchant to after
+	// authorizing the app, it must match the one registered for the app.
+	RedirectURL string
+	// Scopes are the permissions requested to the merchant.
+	Scopes []mollie.PermissionGrant
+	// Endpoint defaults to OauthEndpoint.
+	Endpoint *oauth2.Endpoint
+	// RevokeURL defaults to Mollie's tokens URL.
+	RevokeURL string
+	// HTTPClient is used for the token requests, defaults to http.DefaultClient.
+	HTTPClient *http.Client
+	// Config is used to create the clients of connected organizations,
+	// defaults to a live configuration without idempotency keys.
+	Config *mollie.Config
+}
+
+// OAuth2Config returns the oauth2.Config of the app.
+func (a *App) OAuth2Config() *oauth2.Config {
+	endpoint := a.Endpoint
+	if endpoint == nil {
+		endpoint = OauthEndpoint()
+	}
+
+	scopes := make([]string, len(a.Scopes))
+	for i, s := range a.Scopes {
+		scopes[i] = string(s)
+	}
+
+	return &oauth2.Config{
+		ClientID:     a.ClientID,
+		ClientSecret: a.ClientSecret,
+		RedirectURL:  a.RedirectURL,
+		Scopes:       scopes,
+		Endpoint:     *endpoint,
+	}
+}
+
+// AuthCodeURL returns the URL of the authorize page the merchant is sent to.
+//
+// The state must be unique for every authorization, see NewState.
+func (a *App) AuthCodeURL(state string, prompt mollie.ApprovalPromptAction) string {
+	var opts []oauth2.AuthCodeOption
+	if prompt != "" {
+		opts = append(opts, oauth2.SetAuthURLParam("approval_prompt", string(prompt)))
+	}
+
+	return a.OAuth2Config().AuthCodeURL(state, opts...)
+}
+
+// ClientLinkURL returns the URL a merchant is sent to after creating a
+// client link, it preserves the query of the client link href.
+//
+// See: https://docs.mollie.com/reference/create-client-link
+func (a *App) ClientLinkURL(clientLink, state string, prompt mollie.ApprovalPromptAction) (string, error) {
+	u, err := url.Parse(clientLink)
+	if err != nil {
+		return "", fmt.Errorf("connect: invalid client link: %w", err)
+	}
+
+	scopes := make([]string, len(a.Scopes))
+	for i, s := range a.Scopes {
+		scopes[i] = string(s)
+	}
+
+	qp := u.Query()
+	qp.Set("clientId", a.ClientID)
+	qp.Set("state", state)
+	qp.Set("scope", strings.Join(scopes, " "))
+
+	if prompt != "" {
+		qp.Set("approvalPrompt", string(prompt))
+	}
+
+	u.RawQuery = qp.Encode()
+
+	return u.String(), nil
+}
+
+func (a *App) context(ctx context.Context) context.Context {
+	if a.HTTPClient == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, oauth2.HTTPClient, a.HTTPClient)
+}
+
+// Exchange exchanges the authorization code received on the redirect URL
+// for an access and a refresh token.
+func (a *App) Exchange(ctx context.Context, code string) (*oauth2.Token, error) {
+	return a.OAuth2Config().Exchange(a.context(ctx), code)
+}
+
+// Refresh returns a new access token using a refresh token.
+func (a *App) Refresh(ctx context.Context, refreshToken string) (*oauth2.Token, error) {
+	return a.OAuth2Config().TokenSource(a.context(ctx), &oauth2.Token{RefreshToken: refreshToken}).Token()
+}
+
+// TokenSource returns a source refreshing the access token when it expires.
+func (a *App) TokenSource(ctx context.Context, tkn *oauth2.Token) oauth2.TokenSource {
+	return a.OAuth2Config().TokenSource(a.context(ctx), tkn)
+}
+
+// Revoke revokes an access or refresh token, revoking a refresh token also
+// revokes the access tokens created with it.
+//
+// See: https://docs.mollie.com/reference/revoke-token
+func (a *App) Revoke(ctx context.Context, token string, hint TokenTypeHint) error {
+	revokeURL := a.RevokeURL
+	if revokeURL == "" {
+		revokeURL = tokensURL
+	}
+
+	form := url.Values{"token": {token}, "token_type_hint": {string(hint)}}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, revokeURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("connect: %w", err)
+	}
+
+	req.SetBasicAuth(url.QueryEscape(a.ClientID), url.QueryEscape(a.ClientSecret))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	hc := a.HTTPClient
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+
+	res, err := hc.Do(req)
+	if err != nil {
+		return fmt.Errorf("connect: revoking token: %w", err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusMultipleChoices {
+		body, _ := io.ReadAll(io.LimitReader(res.Body, 1<<10))
+
+		return fmt.Errorf("connect: revoking token: %s: %s", res.Status, body)
+	}
+
+	return nil
+}
+
+// Client returns a client acting on behalf of the organization that granted
+// the token, the access token is refreshed automatically when it expires.
+func (a *App) Client(ctx context.Context, tkn *oauth2.Token) (*mollie.Client, error) {
+	conf := a.Config
+	if conf == nil {
+		conf = mollie.NewConfig(false, "")
+	}
+
+	c, err := mollie.NewClient(a.HTTPClient, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	c.SetAuthProvider(mollie.NewTokenSourceProvider(a.TokenSource(context.WithoutCancel(ctx), tkn)))
+
+	return c, nil
+}
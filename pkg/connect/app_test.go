@@ -0,0 +1,165 @@
+package connect
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const (
+	testClientID     = "app_j9Pakf56Ajta6Y65AkdTtAv"
+	testClientSecret = "S6uQtmbd2L6ZYS2FhxHAuEptmPjq6MnW"
+)
+
+// newTokenServer fakes Mollie's tokens endpoint.
+func newTokenServer(t *testing.T) (*httptest.Server, *[]url.Values) {
+	t.Helper()
+
+	var calls []url.Values
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, secret, ok := r.BasicAuth()
+		if !ok || id != testClientID || secret != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+
+			return
+		}
+
+		if r.Method == http.MethodDelete {
+			// ParseForm ignores the body of DELETE requests.
+			b, _ := io.ReadAll(r.Body)
+			form, err := url.ParseQuery(string(b))
+			require.Nil(t, err)
+
+			calls = append(calls, form)
+			w.WriteHeader(http.StatusNoContent)
+
+			return
+		}
+
+		require.Nil(t, r.ParseForm())
+		calls = append(calls, r.PostForm)
+
+		switch r.PostForm.Get("grant_type") {
+		case "authorization_code":
+			if r.PostForm.Get("code") != "auth_IbyEKUrXmGW1J8hPg6Ciyo4aaU6OAu" {
+				w.WriteHeader(http.StatusBadRequest)
+				_, _ = w.Write([]byte(`{"error":"invalid_grant"}`))
+
+				return
+			}
+		case "refresh_token":
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"access_token":"access_%d","refresh_token":"refresh_46Dbh3bRUw",`+
+			`"token_type":"bearer","expires_in":3600,"scope":"payments.read"}`, len(calls))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv, &calls
+}
+
+func newTestApp(srv *httptest.Server) *App {
+	return &App{
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  "https://example.org/connect/callback",
+		Scopes:       []mollie.PermissionGrant{mollie.PaymentsRead, mollie.RefundsWrite},
+		Endpoint: &oauth2.Endpoint{
+			AuthURL:   authURL,
+			TokenURL:  srv.URL,
+			AuthStyle: oauth2.AuthStyleInHeader,
+		},
+		RevokeURL: srv.URL,
+	}
+}
+
+func TestApp_AuthCodeURL(t *testing.T) {
+	app := &App{
+		ClientID:    testClientID,
+		RedirectURL: "https://example.org/connect/callback",
+		Scopes:      []mollie.PermissionGrant{mollie.PaymentsRead, mollie.RefundsWrite},
+	}
+
+	u, err := url.Parse(app.AuthCodeURL("state_123", mollie.ForceApproval))
+	require.Nil(t, err)
+
+	assert.Equal(t, "www.mollie.com", u.Host)
+	assert.Equal(t, "/oauth2/authorize", u.Path)
+	assert.Equal(t, url.Values{
+		"client_id":       {testClientID},
+		"redirect_uri":    {"https://example.org/connect/callback"},
+		"response_type":   {"code"},
+		"scope":           {"payments.read refunds.write"},
+		"state":           {"state_123"},
+		"approval_prompt": {"force"},
+	}, u.Query())
+}
+
+func TestApp_ClientLinkURL(t *testing.T) {
+	app := &App{
+		ClientID: testClientID,
+		Scopes:   []mollie.PermissionGrant{mollie.OnboardingRead, mollie.OrganizationsRead},
+	}
+
+	got, err := app.ClientLinkURL(
+		"https://my.mollie.com/dashboard/client-link/finalize/csr_vZCnNQsV2UtfXxYifWKWH?lang=nl",
+		"state_123",
+		"",
+	)
+	require.Nil(t, err)
+
+	u, err := url.Parse(got)
+	require.Nil(t, err)
+	assert.Equal(t, "/dashboard/client-link/finalize/csr_vZCnNQsV2UtfXxYifWKWH", u.Path)
+	assert.Equal(t, url.Values{
+		"lang":     {"nl"},
+		"clientId": {testClientID},
+		"state":    {"state_123"},
+		"scope":    {"onboarding.read organizations.read"},
+	}, u.Query())
+
+	_, err = app.ClientLinkURL(":", "state_123", "")
+	assert.NotNil(t, err)
+}
+
+func TestApp_ExchangeRefreshRevoke(t *testing.T) {
+	srv, calls := newTokenServer(t)
+	app := newTestApp(srv)
+
+	tkn, err := app.Exchange(context.Background(), "auth_IbyEKUrXmGW1J8hPg6Ciyo4aaU6OAu")
+	require.Nil(t, err)
+	assert.Equal(t, "access_1", tkn.AccessToken)
+	assert.Equal(t, "refresh_46Dbh3bRUw", tkn.RefreshToken)
+
+	_, err = app.Exchange(context.Background(), "auth_invalid")
+	assert.NotNil(t, err)
+
+	tkn, err = app.Refresh(context.Background(), tkn.RefreshToken)
+	require.Nil(t, err)
+	assert.Equal(t, "access_3", tkn.AccessToken)
+	assert.Equal(t, "refresh_46Dbh3bRUw", (*calls)[2].Get("refresh_token"))
+
+	require.Nil(t, app.Revoke(context.Background(), tkn.RefreshToken, RefreshTokenHint))
+	assert.Equal(t, url.Values{
+		"token":           {"refresh_46Dbh3bRUw"},
+		"token_type_hint": {"refresh_token"},
+	}, (*calls)[3])
+
+	app.ClientSecret = "wrong"
+	assert.ErrorContains(t, app.Revoke(context.Background(), "access_3", AccessTokenHint), "401 Unauthorized")
+}
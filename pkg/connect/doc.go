@@ -0,0 +1,7 @@
+// Package connect contains helpers to implement the Mollie Connect OAuth
+// flow: sending merchants to the authorize page, verifying the state of the
+// redirect, exchanging, refreshing and revoking tokens and creating clients
+// acting on behalf of the connected organizations.
+//
+// See: https://docs.mollie.com/docs/connect-overview
+package connect
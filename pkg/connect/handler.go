@@ -0,0 +1,163 @@
+package connect
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"golang.org/x/oauth2"
+)
+
+// StateCookie is the name of the cookie binding the state of an
+// authorization to the browser of the merchant.
+const StateCookie = "mollie_connect_state"
+
+// Errors returned while handling the redirect of the authorize page.
+var (
+	ErrInvalidState = errors.New("connect: missing or invalid state")
+	ErrMissingCode  = errors.New("connect: missing authorization code")
+)
+
+// AuthorizationError is returned when the merchant did not authorize the app.
+type AuthorizationError struct {
+	Code        string
+	Description string
+}
+
+// Error implements the error interface.
+func (e *AuthorizationError) Error() string {
+	if e.Description == "" {
+		return "connect: authorization failed: " + e.Code
+	}
+
+	return fmt.Sprintf("connect: authorization failed: %s: %s", e.Code, e.Description)
+}
+
+// NewState returns a random state to protect an authorization against
+// cross-site request forgery.
+func NewState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("connect: generating state: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// VerifyState reports whether the state received on the redirect URL matches
+// the one sent to the authorize page, in constant time.
+func VerifyState(expected, got string) bool {
+	return expected != "" && subtle.ConstantTimeCompare([]byte(expected), []byte(got)) == 1
+}
+
+// AuthorizeHandler returns a handler sending the merchant to the authorize
+// page, the state is stored in the StateCookie to be verified by the
+// CallbackHandler.
+func (a *App) AuthorizeHandler(prompt mollie.ApprovalPromptAction) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		state, err := NewState()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+
+			return
+		}
+
+		http.SetCookie(w, &http.Cookie{
+			Name:     StateCookie,
+			Value:    state,
+			Path:     "/",
+			MaxAge:   600,
+			Secure:   true,
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+
+		http.Redirect(w, r, a.AuthCodeURL(state, prompt), http.StatusFound)
+	})
+}
+
+// Connection is the result of a successful authorization.
+type Connection struct {
+	// Client acts on behalf of the organization that authorized the app.
+	Client *mollie.Client
+	// Token should be persisted to create new clients later on.
+	Token *oauth2.Token
+}
+
+// Callback receives the connection of an organization after a successful
+// authorization, it writes the response sent to the merchant.
+type Callback func(w http.ResponseWriter, r *http.Request, conn *Connection)
+
+// ErrorCallback receives the errors occurred while handling the redirect.
+type ErrorCallback func(w http.ResponseWriter, r *http.Request, err error)
+
+// CallbackHandler returns the handler of the redirect URL: it verifies the
+// state, exchanges the authorization code and passes a client for the new
+// organization to onConnect.
+//
+// Errors are passed to onError, when nil a plain text error is sent with
+// status 400 for invalid or denied authorizations and 502 when the code
+// can not be exchanged.
+func (a *App) CallbackHandler(onConnect Callback, onError ErrorCallback) http.Handler {
+	if onError == nil {
+		onError = defaultErrorCallback
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// a state can only be used once.
+		http.SetCookie(w, &http.Cookie{Name: StateCookie, Path: "/", MaxAge: -1})
+
+		conn, err := a.connect(r)
+		if err != nil {
+			onError(w, r, err)
+
+			return
+		}
+
+		onConnect(w, r, conn)
+	})
+}
+
+func (a *App) connect(r *http.Request) (*Connection, error) {
+	cookie, err := r.Cookie(StateCookie)
+	if err != nil || !VerifyState(cookie.Value, r.URL.Query().Get("state")) {
+		return nil, ErrInvalidState
+	}
+
+	if code := r.URL.Query().Get("error"); code != "" {
+		return nil, &AuthorizationError{Code: code, Description: r.URL.Query().Get("error_description")}
+	}
+
+	code := r.URL.Query().Get("code")
+	if code == "" {
+		return nil, ErrMissingCode
+	}
+
+	tkn, err := a.Exchange(r.Context(), code)
+	if err != nil {
+		return nil, fmt.Errorf("connect: exchanging code: %w", err)
+	}
+
+	c, err := a.Client(context.WithoutCancel(r.Context()), tkn)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Connection{Client: c, Token: tkn}, nil
+}
+
+func defaultErrorCallback(w http.ResponseWriter, _ *http.Request, err error) {
+	status := http.StatusBadGateway
+
+	var ae *AuthorizationError
+	if errors.Is(err, ErrInvalidState) || errors.Is(err, ErrMissingCode) || errors.As(err, &ae) {
+		status = http.StatusBadRequest
+	}
+
+	http.Error(w, err.Error(), status)
+}
@@ -0,0 +1,100 @@
+package connect
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestState(t *testing.T) {
+	s1, err := NewState()
+	require.Nil(t, err)
+	s2, err := NewState()
+	require.Nil(t, err)
+
+	assert.Len(t, s1, 43)
+	assert.NotEqual(t, s1, s2)
+	assert.True(t, VerifyState(s1, s1))
+	assert.False(t, VerifyState(s1, s2))
+	assert.False(t, VerifyState("", ""))
+}
+
+func TestApp_AuthorizeHandler(t *testing.T) {
+	srv, _ := newTokenServer(t)
+	app := newTestApp(srv)
+
+	w := httptest.NewRecorder()
+	app.AuthorizeHandler("").ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/connect", nil))
+
+	require.Equal(t, http.StatusFound, w.Code)
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, StateCookie, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+	assert.True(t, cookies[0].Secure)
+
+	loc, err := url.Parse(w.Header().Get("Location"))
+	require.Nil(t, err)
+	assert.Equal(t, cookies[0].Value, loc.Query().Get("state"))
+}
+
+func TestApp_CallbackHandler(t *testing.T) {
+	srv, _ := newTokenServer(t)
+	app := newTestApp(srv)
+
+	callback := func(query string, state string) (*Connection, *httptest.ResponseRecorder) {
+		var conn *Connection
+
+		h := app.CallbackHandler(
+			func(w http.ResponseWriter, _ *http.Request, c *Connection) {
+				conn = c
+				w.WriteHeader(http.StatusNoContent)
+			},
+			nil,
+		)
+
+		r := httptest.NewRequest(http.MethodGet, "/connect/callback?"+query, nil)
+		if state != "" {
+			r.AddCookie(&http.Cookie{Name: StateCookie, Value: state})
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return conn, w
+	}
+
+	conn, w := callback("code=auth_IbyEKUrXmGW1J8hPg6Ciyo4aaU6OAu&state=abc", "")
+	assert.Nil(t, conn)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	_, w = callback("code=auth_IbyEKUrXmGW1J8hPg6Ciyo4aaU6OAu&state=abc", "xyz")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), ErrInvalidState.Error())
+
+	_, w = callback("error=access_denied&error_description=denied&state=abc", "abc")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "access_denied: denied")
+
+	_, w = callback("code=auth_invalid&state=abc", "abc")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+
+	conn, w = callback("code=auth_IbyEKUrXmGW1J8hPg6Ciyo4aaU6OAu&state=abc", "abc")
+	require.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "access_2", conn.Token.AccessToken)
+	assert.True(t, conn.Client.HasAccessToken())
+
+	req, err := conn.Client.NewAPIRequest(context.Background(), http.MethodGet, "v2/organizations/me", nil)
+	require.Nil(t, err)
+	assert.Equal(t, "Bearer access_2", req.Header.Get("Authorization"))
+
+	cleared := w.Result().Cookies()
+	require.Len(t, cleared, 1)
+	assert.Equal(t, -1, cleared[0].MaxAge)
+}
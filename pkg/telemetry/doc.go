@@ -0,0 +1,11 @@
+// Package telemetry instruments the Mollie API client with traces and metrics.
+//
+// The package does not depend on a specific telemetry SDK, the Tracer and
+// Meter interfaces mirror the subset of OpenTelemetry used by the
+// instrumentation so adapting an OpenTelemetry tracer or meter only takes
+// a few lines.
+//
+// Spans and metrics never contain request headers, request bodies or query
+// strings, so neither the Authorization header nor customer data can leak
+// into the telemetry backend.
+package telemetry
@@ -0,0 +1,13 @@
+// Package otelmollie backs the instrumentation of the telemetry package
+// with OpenTelemetry.
+//
+// Every call made by the client becomes a client span named after the
+// operation, e.g. Payments.Create, and is recorded in the
+// mollie.client.request.duration histogram. Failed calls are also counted
+// in mollie.client.request.errors.
+//
+// The package is a separate module, so the OpenTelemetry dependencies are
+// only added to the programs using it. It requires a version of the core
+// module providing mollie.Middleware and the telemetry package, the first
+// one after v4.16.0.
+package otelmollie
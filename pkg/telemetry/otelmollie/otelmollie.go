@@ -0,0 +1,162 @@
+package otelmollie
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v4/pkg/telemetry"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer and meter.
+const ScopeName = "github.com/VictorAvelar/mollie-api-go/v4/pkg/telemetry/otelmollie"
+
+// Names of the recorded metrics.
+const (
+	DurationMetric = "mollie.client.request.duration"
+	ErrorsMetric   = "mollie.client.request.errors"
+)
+
+// Middleware returns a client middleware tracing and measuring every API
+// call with OpenTelemetry, nil providers are replaced by the global ones.
+//
+//	mw, err := otelmollie.Middleware(nil, nil)
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//
+//	client.Use(mw)
+func Middleware(tp trace.TracerProvider, mp metric.MeterProvider) (mollie.Middleware, error) {
+	m, err := NewMeter(mp)
+	if err != nil {
+		return nil, err
+	}
+
+	return telemetry.Middleware(NewTracer(tp), m), nil
+}
+
+// NewTracer returns a telemetry.Tracer starting client spans with the
+// tracer of tp, the global provider is used when tp is nil.
+func NewTracer(tp trace.TracerProvider) telemetry.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+
+	return tracer{tp.Tracer(ScopeName)}
+}
+
+type tracer struct {
+	tracer trace.Tracer
+}
+
+// Start starts a client span.
+func (t tracer) Start(ctx context.Context, name string) (context.Context, telemetry.Span) {
+	ctx, s := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+
+	return ctx, span{s}
+}
+
+type span struct {
+	span trace.Span
+}
+
+// SetAttributes converts the attributes to OpenTelemetry attributes.
+func (s span) SetAttributes(attrs ...telemetry.Attribute) {
+	s.span.SetAttributes(convert(attrs)...)
+}
+
+// RecordError records err and marks the span as failed.
+func (s span) RecordError(err error) {
+	s.span.RecordError(err)
+	s.span.SetStatus(codes.Error, telemetry.ErrorClass(err))
+}
+
+// End ends the span.
+func (s span) End() {
+	s.span.End()
+}
+
+// NewMeter returns a telemetry.Meter recording the duration of the calls in
+// a histogram and the failed calls in a counter, using the meter of mp or
+// of the global provider when mp is nil.
+//
+// The resource ID is not recorded on metrics to keep their cardinality low.
+func NewMeter(mp metric.MeterProvider) (telemetry.Meter, error) {
+	if mp == nil {
+		mp = otel.GetMeterProvider()
+	}
+
+	m := mp.Meter(ScopeName)
+
+	duration, err := m.Float64Histogram(DurationMetric,
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of the calls made to the Mollie API."))
+	if err != nil {
+		return nil, fmt.Errorf("otelmollie: creating %s: %w", DurationMetric, err)
+	}
+
+	errs, err := m.Int64Counter(ErrorsMetric,
+		metric.WithUnit("{call}"),
+		metric.WithDescription("Number of failed calls made to the Mollie API."))
+	if err != nil {
+		return nil, fmt.Errorf("otelmollie: creating %s: %w", ErrorsMetric, err)
+	}
+
+	return meter{duration: duration, errors: errs}, nil
+}
+
+type meter struct {
+	duration metric.Float64Histogram
+	errors   metric.Int64Counter
+}
+
+// RecordLatency records d in seconds.
+func (m meter) RecordLatency(ctx context.Context, d time.Duration, attrs ...telemetry.Attribute) {
+	m.duration.Record(ctx, d.Seconds(), metric.WithAttributes(metricAttributes(attrs)...))
+}
+
+// AddError counts a failed call.
+func (m meter) AddError(ctx context.Context, attrs ...telemetry.Attribute) {
+	m.errors.Add(ctx, 1, metric.WithAttributes(metricAttributes(attrs)...))
+}
+
+func metricAttributes(attrs []telemetry.Attribute) []attribute.KeyValue {
+	kept := make([]telemetry.Attribute, 0, len(attrs))
+
+	for _, a := range attrs {
+		if a.Key != telemetry.ResourceIDKey {
+			kept = append(kept, a)
+		}
+	}
+
+	return convert(kept)
+}
+
+func convert(attrs []telemetry.Attribute) []attribute.KeyValue {
+	kvs := make([]attribute.KeyValue, len(attrs))
+
+	for i, a := range attrs {
+		switch v := a.Value.(type) {
+		case string:
+			kvs[i] = attribute.String(a.Key, v)
+		case int:
+			kvs[i] = attribute.Int(a.Key, v)
+		case int64:
+			kvs[i] = attribute.Int64(a.Key, v)
+		case bool:
+			kvs[i] = attribute.Bool(a.Key, v)
+		case float64:
+			kvs[i] = attribute.Float64(a.Key, v)
+		default:
+			kvs[i] = attribute.String(a.Key, fmt.Sprint(v))
+		}
+	}
+
+	return kvs
+}
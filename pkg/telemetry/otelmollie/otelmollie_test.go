@@ -0,0 +1,125 @@
+package otelmollie
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v4/pkg/telemetry"
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestMiddleware(t *testing.T) {
+	t.Setenv(mollie.APITokenEnv, "test_secret_token")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	})
+	mux.HandleFunc("/v2/payments/tr_missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(testdata.NotFoundErrorResponse))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := mollie.NewClient(nil, mollie.NewAPITestingConfig(false))
+	require.Nil(t, err)
+
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+
+	mw, err := Middleware(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	require.Nil(t, err)
+	client.Use(mw)
+
+	_, _, err = client.Payments.Create(context.Background(), mollie.CreatePayment{}, nil)
+	require.Nil(t, err)
+
+	_, _, err = client.Payments.Get(context.Background(), "tr_missing", nil)
+	assert.NotNil(t, err)
+
+	ended := spans.Ended()
+	require.Len(t, ended, 2)
+
+	created := ended[0]
+	assert.Equal(t, "Payments.Create", created.Name())
+	assert.Equal(t, trace.SpanKindClient, created.SpanKind())
+	assert.Equal(t, codes.Unset, created.Status().Code)
+	assert.Contains(t, created.Attributes(), attribute.String(telemetry.ResourceIDKey, "tr_WDqYK6vllg"))
+	assert.Contains(t, created.Attributes(), attribute.Int(telemetry.StatusCodeKey, http.StatusCreated))
+
+	missing := ended[1]
+	assert.Equal(t, "Payments.Get", missing.Name())
+	assert.Equal(t, codes.Error, missing.Status().Code)
+	assert.Equal(t, telemetry.NotFoundError, missing.Status().Description)
+
+	var rm metricdata.ResourceMetrics
+	require.Nil(t, reader.Collect(context.Background(), &rm))
+	require.Len(t, rm.ScopeMetrics, 1)
+
+	metrics := map[string]metricdata.Aggregation{}
+	for _, m := range rm.ScopeMetrics[0].Metrics {
+		metrics[m.Name] = m.Data
+	}
+
+	duration, ok := metrics[DurationMetric].(metricdata.Histogram[float64])
+	require.True(t, ok)
+
+	var calls uint64
+	for _, dp := range duration.DataPoints {
+		calls += dp.Count
+
+		_, found := dp.Attributes.Value(telemetry.ResourceIDKey)
+		assert.False(t, found)
+	}
+
+	assert.Equal(t, uint64(2), calls)
+
+	errs, ok := metrics[ErrorsMetric].(metricdata.Sum[int64])
+	require.True(t, ok)
+	require.Len(t, errs.DataPoints, 1)
+	assert.Equal(t, int64(1), errs.DataPoints[0].Value)
+
+	class, _ := errs.DataPoints[0].Attributes.Value(telemetry.ErrorTypeKey)
+	assert.Equal(t, telemetry.NotFoundError, class.AsString())
+}
+
+func TestConvert(t *testing.T) {
+	kvs := convert([]telemetry.Attribute{
+		{Key: "s", Value: "v"},
+		{Key: "i", Value: 1},
+		{Key: "i64", Value: int64(2)},
+		{Key: "b", Value: true},
+		{Key: "f", Value: 1.5},
+		{Key: "mode", Value: mollie.TestMode},
+	})
+
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("s", "v"),
+		attribute.Int("i", 1),
+		attribute.Int64("i64", 2),
+		attribute.Bool("b", true),
+		attribute.Float64("f", 1.5),
+		attribute.String("mode", "test"),
+	}, kvs)
+}
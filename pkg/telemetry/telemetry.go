@@ -0,0 +1,285 @@
+package telemetry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+)
+
+// Attribute keys recorded on spans and metrics.
+const (
+	OperationKey  = "mollie.operation"
+	ResourceIDKey = "mollie.resource.id"
+	ModeKey       = "mollie.mode"
+	MethodKey     = "http.request.method"
+	StatusCodeKey = "http.response.status_code"
+	ErrorTypeKey  = "error.type"
+)
+
+// Error classes recorded with the ErrorTypeKey attribute.
+const (
+	NotFoundError      = "not_found"
+	UnauthorizedError  = "unauthorized"
+	RateLimitedError   = "rate_limited"
+	UnprocessableError = "unprocessable"
+	ServerError        = "server_error"
+	ClientError        = "client_error"
+	CanceledError      = "canceled"
+	TimeoutError       = "timeout"
+	TransportError     = "transport"
+)
+
+// Attribute is a key value pair describing a call.
+type Attribute struct {
+	Key   string
+	Value any
+}
+
+// Span is the part of a trace describing a single API call.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	RecordError(err error)
+	End()
+}
+
+// Tracer starts the spans of the instrumented calls.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Meter records the metrics of the instrumented calls.
+type Meter interface {
+	// RecordLatency records the duration of every call.
+	RecordLatency(ctx context.Context, d time.Duration, attrs ...Attribute)
+	// AddError counts the failed calls.
+	AddError(ctx context.Context, attrs ...Attribute)
+}
+
+// Middleware returns a client middleware creating a span and recording
+// metrics for every API call, either the tracer or the meter can be nil.
+//
+// Register it using Client.Use:
+//
+//	client.Use(telemetry.Middleware(tracer, meter))
+func Middleware(tracer Tracer, meter Meter) mollie.Middleware {
+	return func(next mollie.Doer) mollie.Doer {
+		return func(req *http.Request) (*mollie.Response, error) {
+			op := Operation(req.Method, req.URL.Path)
+			ctx := req.Context()
+
+			var span Span
+			if tracer != nil {
+				ctx, span = tracer.Start(ctx, op)
+				req = req.WithContext(ctx)
+			}
+
+			start := time.Now()
+			res, err := next(req)
+			elapsed := time.Since(start)
+
+			attrs := attributes(op, req, res, err)
+
+			if span != nil {
+				span.SetAttributes(attrs...)
+
+				if err != nil {
+					span.RecordError(err)
+				}
+
+				span.End()
+			}
+
+			if meter != nil {
+				meter.RecordLatency(ctx, elapsed, attrs...)
+
+				if err != nil {
+					meter.AddError(ctx, attrs...)
+				}
+			}
+
+			return res, err
+		}
+	}
+}
+
+// Operation returns the name of the operation performed by a request,
+// derived from its path, e.g. a POST request to /v2/payments/tr_WDqYK6vllg/refunds
+// is Refunds.Create and a DELETE request to /v2/payments/tr_WDqYK6vllg is
+// Payments.Cancel.
+//
+// Paths alternate between collections and resource IDs, the last collection
+// names the resource. Singular segments following a resource, such as
+// report in /v2/balances/bal_gVMhHKqSSRYJyPsuoPNFH/report, and well known
+// IDs, such as all in /v2/methods/all, name the action instead.
+func Operation(method, path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(segments) > 1 && strings.HasPrefix(segments[0], "v") {
+		segments = segments[1:]
+	}
+
+	res, action, single := segments[0], "", false
+
+	for i, s := range segments[1:] {
+		switch {
+		case i%2 == 1 && !strings.HasSuffix(s, "s"):
+			action, single = title(s), false
+		case i%2 == 1:
+			res, action, single = s, "", false
+		case namedIDs[s] && i == len(segments)-2:
+			action = title(s)
+		default:
+			single = true
+		}
+	}
+
+	if action == "" {
+		action = methodAction(method, res, single)
+	}
+
+	return title(res) + "." + action
+}
+
+// namedIDs are the path segments standing for a well known resource.
+var namedIDs = map[string]bool{"all": true, "me": true, "primary": true, "open": true, "next": true}
+
+// deleteActions name the DELETE requests that do not delete the resource.
+var deleteActions = map[string]string{
+	"payments":      "Cancel",
+	"refunds":       "Cancel",
+	"orders":        "Cancel",
+	"lines":         "Cancel",
+	"subscriptions": "Cancel",
+	"mandates":      "Revoke",
+	"methods":       "Disable",
+	"issuers":       "Disable",
+}
+
+func methodAction(method, res string, single bool) string {
+	switch {
+	case method == http.MethodGet && single:
+		return "Get"
+	case method == http.MethodGet:
+		return "List"
+	case method == http.MethodPost && single && (res == "methods" || res == "issuers"):
+		return "Enable"
+	case method == http.MethodPost:
+		return "Create"
+	case method == http.MethodPatch:
+		return "Update"
+	case method == http.MethodDelete && deleteActions[res] != "":
+		return deleteActions[res]
+	case method == http.MethodDelete:
+		return "Delete"
+	default:
+		return method
+	}
+}
+
+// title converts a path segment to an operation name, e.g. payment-links
+// becomes PaymentLinks.
+func title(segment string) string {
+	var b strings.Builder
+
+	for part := range strings.SplitSeq(segment, "-") {
+		if part == "" {
+			continue
+		}
+
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+
+	return b.String()
+}
+
+func attributes(op string, req *http.Request, res *mollie.Response, err error) []Attribute {
+	attrs := []Attribute{
+		{OperationKey, op},
+		{MethodKey, req.Method},
+	}
+
+	if res != nil && res.Response != nil {
+		attrs = append(attrs, Attribute{StatusCodeKey, res.StatusCode})
+	}
+
+	id, mode := resource(req, res)
+	if id != "" {
+		attrs = append(attrs, Attribute{ResourceIDKey, id})
+	}
+
+	if mode != "" {
+		attrs = append(attrs, Attribute{ModeKey, mode})
+	}
+
+	if err != nil {
+		attrs = append(attrs, Attribute{ErrorTypeKey, ErrorClass(err)})
+	}
+
+	return attrs
+}
+
+// resource returns the ID and mode of the resource returned by Mollie,
+// falling back to the ID in the request path.
+func resource(req *http.Request, res *mollie.Response) (id string, mode mollie.Mode) {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if last := segments[len(segments)-1]; strings.Contains(last, "_") {
+		id = last
+	}
+
+	if res == nil || res.Response == nil || res.Body == nil {
+		return id, ""
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return id, ""
+	}
+
+	var r struct {
+		Resource string      `json:"resource"`
+		ID       string      `json:"id"`
+		Mode     mollie.Mode `json:"mode"`
+	}
+
+	if json.Unmarshal(body, &r) != nil || r.Resource == "" {
+		return id, ""
+	}
+
+	return r.ID, r.Mode
+}
+
+// ErrorClass returns a low cardinality description of err suitable
+// for the ErrorTypeKey attribute.
+func ErrorClass(err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return CanceledError
+	case errors.Is(err, context.DeadlineExceeded):
+		return TimeoutError
+	case errors.Is(err, mollie.ErrNotFound):
+		return NotFoundError
+	case errors.Is(err, mollie.ErrUnauthorized):
+		return UnauthorizedError
+	case errors.Is(err, mollie.ErrRateLimited):
+		return RateLimitedError
+	case errors.Is(err, mollie.ErrUnprocessable):
+		return UnprocessableError
+	case errors.Is(err, mollie.ErrServer):
+		return ServerError
+	}
+
+	var be *mollie.BaseError
+	if errors.As(err, &be) {
+		return ClientError
+	}
+
+	return TransportError
+}
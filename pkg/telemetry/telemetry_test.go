@@ -0,0 +1,163 @@
+package telemetry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/VictorAvelar/mollie-api-go/v4/mollie"
+	"github.com/VictorAvelar/mollie-api-go/v4/testdata"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeSpan struct {
+	name  string
+	attrs map[string]any
+	err   error
+	ended bool
+}
+
+func (s *fakeSpan) SetAttributes(attrs ...Attribute) {
+	for _, a := range attrs {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func (s *fakeSpan) RecordError(err error) { s.err = err }
+
+func (s *fakeSpan) End() { s.ended = true }
+
+type fakeTracer struct {
+	spans []*fakeSpan
+}
+
+func (t *fakeTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &fakeSpan{name: name, attrs: map[string]any{}}
+	t.spans = append(t.spans, s)
+
+	return ctx, s
+}
+
+type fakeMeter struct {
+	latencies int
+	errors    []string
+}
+
+func (m *fakeMeter) RecordLatency(ctx context.Context, d time.Duration, attrs ...Attribute) {
+	m.latencies++
+}
+
+func (m *fakeMeter) AddError(ctx context.Context, attrs ...Attribute) {
+	for _, a := range attrs {
+		if a.Key == ErrorTypeKey {
+			m.errors = append(m.errors, a.Value.(string))
+		}
+	}
+}
+
+func TestOperation(t *testing.T) {
+	cases := []struct {
+		method, path, want string
+	}{
+		{http.MethodPost, "/v2/payments", "Payments.Create"},
+		{http.MethodGet, "/v2/payments", "Payments.List"},
+		{http.MethodGet, "/v2/payments/tr_WDqYK6vllg", "Payments.Get"},
+		{http.MethodPatch, "/v2/payments/tr_WDqYK6vllg", "Payments.Update"},
+		{http.MethodDelete, "/v2/payments/tr_WDqYK6vllg", "Payments.Cancel"},
+		{http.MethodPost, "/v2/payments/tr_WDqYK6vllg/refunds", "Refunds.Create"},
+		{http.MethodDelete, "/v2/payments/tr_WDqYK6vllg/refunds/re_4qqhO89gsT", "Refunds.Cancel"},
+		{http.MethodDelete, "/v2/customers/cst_8wmqcHMN4U/mandates/mdt_h3gAaD5zP", "Mandates.Revoke"},
+		{http.MethodDelete, "/v2/customers/cst_8wmqcHMN4U", "Customers.Delete"},
+		{http.MethodGet, "/v2/payment-links/pl_4Y0eZitmBnQ6IDoMqZQKh", "PaymentLinks.Get"},
+		{http.MethodGet, "/v2/methods", "Methods.List"},
+		{http.MethodGet, "/v2/methods/all", "Methods.All"},
+		{http.MethodGet, "/v2/methods/ideal", "Methods.Get"},
+		{http.MethodPost, "/v2/profiles/pfl_v9hTwCvYqw/methods/ideal", "Methods.Enable"},
+		{http.MethodDelete, "/v2/profiles/pfl_v9hTwCvYqw/methods/ideal", "Methods.Disable"},
+		{http.MethodGet, "/v2/organizations/me", "Organizations.Me"},
+		{http.MethodGet, "/v2/organizations/me/partner", "Organizations.Partner"},
+		{http.MethodGet, "/v2/balances/primary/transactions", "Transactions.List"},
+		{http.MethodGet, "/v2/balances/bal_gVMhHKqSSRYJyPsuoPNFH/report", "Balances.Report"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.want, func(t *testing.T) {
+			assert.Equal(t, c.want, Operation(c.method, c.path))
+		})
+	}
+}
+
+func TestErrorClass(t *testing.T) {
+	assert.Equal(t, NotFoundError, ErrorClass(&mollie.BaseError{Status: http.StatusNotFound}))
+	assert.Equal(t, ServerError, ErrorClass(&mollie.BaseError{Status: http.StatusBadGateway}))
+	assert.Equal(t, ClientError, ErrorClass(&mollie.BaseError{Status: http.StatusBadRequest}))
+	assert.Equal(t, TimeoutError, ErrorClass(fmt.Errorf("http_error: %w", context.DeadlineExceeded)))
+	assert.Equal(t, TransportError, ErrorClass(fmt.Errorf("connection refused")))
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Setenv(mollie.APITokenEnv, "test_secret_token")
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/v2/payments", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(testdata.GetPaymentResponse))
+	})
+	mux.HandleFunc("/v2/payments/tr_missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(testdata.NotFoundErrorResponse))
+	})
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	client, err := mollie.NewClient(nil, mollie.NewAPITestingConfig(false))
+	require.Nil(t, err)
+
+	client.BaseURL, _ = url.Parse(srv.URL + "/")
+
+	tracer := &fakeTracer{}
+	meter := &fakeMeter{}
+	client.Use(Middleware(tracer, meter))
+
+	_, p, err := client.Payments.Create(context.Background(), mollie.CreatePayment{}, nil)
+	require.Nil(t, err)
+	assert.Equal(t, "tr_WDqYK6vllg", p.ID)
+
+	_, _, err = client.Payments.Get(context.Background(), "tr_missing", nil)
+	assert.NotNil(t, err)
+
+	require.Len(t, tracer.spans, 2)
+
+	created := tracer.spans[0]
+	assert.Equal(t, "Payments.Create", created.name)
+	assert.True(t, created.ended)
+	assert.Nil(t, created.err)
+	assert.Equal(t, map[string]any{
+		OperationKey:  "Payments.Create",
+		MethodKey:     http.MethodPost,
+		StatusCodeKey: http.StatusCreated,
+		ResourceIDKey: "tr_WDqYK6vllg",
+		ModeKey:       mollie.TestMode,
+	}, created.attrs)
+
+	missing := tracer.spans[1]
+	assert.Equal(t, "Payments.Get", missing.name)
+	assert.NotNil(t, missing.err)
+	assert.Equal(t, "tr_missing", missing.attrs[ResourceIDKey])
+	assert.Equal(t, NotFoundError, missing.attrs[ErrorTypeKey])
+
+	for _, s := range tracer.spans {
+		for _, v := range s.attrs {
+			assert.NotContains(t, fmt.Sprint(v), "test_secret_token")
+		}
+	}
+
+	assert.Equal(t, 2, meter.latencies)
+	assert.Equal(t, []string{NotFoundError}, meter.errors)
+}
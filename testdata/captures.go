@@ -157,3 +157,22 @@ const CreateCaptureWithAccessTokenResponse = `{
         }
     }
 }`
+
+// GetCaptureEmbeddedResponse example
+const GetCaptureEmbeddedResponse = `{
+    "resource": "capture",
+    "id": "cpt_mNepDkEtco6ah3QNPUGYH",
+    "amount": {
+        "value": "10.00",
+        "currency": "EUR"
+    },
+    "status": "succeeded",
+    "paymentId": "tr_WDqYK6vllg",
+    "_embedded": {
+        "payment": {
+            "resource": "payment",
+            "id": "tr_WDqYK6vllg",
+            "status": "paid"
+        }
+    }
+}`
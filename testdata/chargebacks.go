@@ -83,3 +83,21 @@ const (
     }
 }`
 )
+
+// GetChargebackEmbeddedResponse example
+const GetChargebackEmbeddedResponse = `{
+    "resource": "chargeback",
+    "id": "chb_n9z0tp",
+    "amount": {
+        "value": "1.00",
+        "currency": "EUR"
+    },
+    "paymentId": "tr_WDqYK6vllg",
+    "_embedded": {
+        "payment": {
+            "resource": "payment",
+            "id": "tr_WDqYK6vllg",
+            "status": "paid"
+        }
+    }
+}`
@@ -1679,3 +1679,39 @@ const ManageOrderLinesResponse = `{
      }
  }
 }`
+
+// GetOrderEmbeddedResponse example
+const GetOrderEmbeddedResponse = `{
+    "resource": "order",
+    "id": "ord_kEn1PlbGa",
+    "status": "shipping",
+    "amount": {
+        "value": "10.00",
+        "currency": "EUR"
+    },
+    "_embedded": {
+        "payments": [
+            {
+                "resource": "payment",
+                "id": "tr_ncaPcAhuUV",
+                "status": "paid",
+                "orderId": "ord_kEn1PlbGa"
+            }
+        ],
+        "refunds": [
+            {
+                "resource": "refund",
+                "id": "re_vD3Jm32wQt",
+                "status": "pending",
+                "orderId": "ord_kEn1PlbGa"
+            }
+        ],
+        "shipments": [
+            {
+                "resource": "shipment",
+                "id": "shp_3wmsgCJN4U",
+                "orderId": "ord_kEn1PlbGa"
+            }
+        ]
+    }
+}`
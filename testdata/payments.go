@@ -173,3 +173,52 @@ const ListPaymentsResponse = `{
         }
     }
 }`
+
+// GetPaymentEmbeddedResponse example
+const GetPaymentEmbeddedResponse = `{
+    "resource": "payment",
+    "id": "tr_WDqYK6vllg",
+    "mode": "test",
+    "amount": {
+        "value": "10.00",
+        "currency": "EUR"
+    },
+    "status": "paid",
+    "_embedded": {
+        "refunds": [
+            {
+                "resource": "refund",
+                "id": "re_4qqhO89gsT",
+                "amount": {
+                    "value": "2.00",
+                    "currency": "EUR"
+                },
+                "status": "pending",
+                "paymentId": "tr_WDqYK6vllg"
+            }
+        ],
+        "chargebacks": [
+            {
+                "resource": "chargeback",
+                "id": "chb_n9z0tp",
+                "amount": {
+                    "value": "1.00",
+                    "currency": "EUR"
+                },
+                "paymentId": "tr_WDqYK6vllg"
+            }
+        ],
+        "captures": [
+            {
+                "resource": "capture",
+                "id": "cpt_mNepDkEtco6ah3QNPUGYH",
+                "amount": {
+                    "value": "10.00",
+                    "currency": "EUR"
+                },
+                "status": "succeeded",
+                "paymentId": "tr_WDqYK6vllg"
+            }
+        ]
+    }
+}`
@@ -420,3 +420,43 @@ const ListSettlementsResponse = `
         }
     }
 }`
+
+// GetSettlementEmbeddedResponse example
+const GetSettlementEmbeddedResponse = `{
+    "resource": "settlement",
+    "id": "stl_jDk30akdN",
+    "status": "paidout",
+    "amount": {
+        "value": "39.75",
+        "currency": "EUR"
+    },
+    "_embedded": {
+        "payments": [
+            {
+                "resource": "payment",
+                "id": "tr_WDqYK6vllg",
+                "settlementId": "stl_jDk30akdN"
+            }
+        ],
+        "refunds": [
+            {
+                "resource": "refund",
+                "id": "re_4qqhO89gsT",
+                "settlementId": "stl_jDk30akdN"
+            }
+        ],
+        "chargebacks": [
+            {
+                "resource": "chargeback",
+                "id": "chb_n9z0tp"
+            }
+        ],
+        "captures": [
+            {
+                "resource": "capture",
+                "id": "cpt_mNepDkEtco6ah3QNPUGYH",
+                "settlementId": "stl_jDk30akdN"
+            }
+        ]
+    }
+}`